@@ -0,0 +1,61 @@
+# ============================================================
+# APO-1 extension: Age-based inventory valuation
+# Inventory is tracked by receipt cohort so that an age-dependent
+# obsolescence cost (e.g. fashion items losing value per week on
+# hand) is charged on top of APO-1's flat holding cost. Sales are
+# drawn from cohorts freely; the cost curve steers them to FIFO.
+# The curve comes from the shared aging.mod / aging.dat, which
+# delist_runoff.mod charges on its markdown runoff as well;
+# aged_cost overrides it per product where needed.
+#
+#   model APO-1.mod;  model extensions/age_holding_cost.mod;
+#   data "Sample 2.dat";  data extensions/aging.dat;
+#   data extensions/age_holding_cost.dat;
+#   solve;  display Ia;
+# ============================================================
+
+# Age in periods since receipt (0 = received this period)
+set AGE := 0..card(PER)-1;
+
+# Obsolescence cost per unit and period at a given age
+model extensions/aging.mod;
+param aged_cost{j in PROD, a in AGE} >= 0 default age_cost[a];
+
+# Receipt cohorts (r received, t current)
+set COH := {r in PER, t in PER: ord(r) <= ord(t)};
+
+# -------- Decision Variables --------
+var Ia{PROD,COH} >= 0;    # end inventory of cohort r in period t
+var sa{PROD,COH} >= 0;    # sales in t drawn from cohort r
+
+# -------- Objective --------
+maximize Profit_Aged:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD, (r,t) in COH} aged_cost[j, ord(t) - ord(r)] * Ia[j,r,t]
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Cohort balance: a cohort starts with its order quantity
+subject to CohortNew{j in PROD, t in PER}:
+    Ia[j,t,t] = u[j,t] - sa[j,t,t];
+
+subject to CohortAge{j in PROD, (r,t) in COH: ord(r) < ord(t)}:
+    Ia[j,r,t] = Ia[j,r,prev(t)] - sa[j,r,t];
+
+# 2) Sales across cohorts equal demand
+subject to CohortSales{j in PROD, t in PER}:
+    sum{(r,t) in COH} sa[j,r,t] = d[j,t];
+
+# 3) Cohorts add up to APO-1's end inventory
+subject to CohortTotal{j in PROD, t in PER}:
+    sum{(r,t) in COH} Ia[j,r,t] = I[j,t];
+
+objective Profit_Aged;
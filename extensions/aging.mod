@@ -0,0 +1,20 @@
+# ============================================================
+# APO-1 extension: Obsolescence curve (shared)
+# One age-dependent cost curve for every model that charges
+# stock by age (age_holding_cost, delist_runoff), read from
+# aging.dat so that they cannot drift apart. The curve is a step
+# function over breakpoints: the cost per unit and period at age
+# a is age_curve at the largest breakpoint not above a.
+# Loaded by the models that use it; load its data once.
+#
+#   data extensions/aging.dat;
+# ============================================================
+
+set AGE_PT ordered;                           # breakpoint ages, from 0
+param age_curve{AGE_PT} >= 0;                 # cost per unit-period
+
+check: card(AGE_PT) > 0 and first(AGE_PT) = 0;
+check{b in AGE_PT: b <> first(AGE_PT)}: b > prev(b);
+
+param age_max integer >= 0 default 520;       # ages looked up
+param age_cost{a in 0..age_max} := age_curve[max{b in AGE_PT: b <= a} b];
@@ -0,0 +1,149 @@
+# ============================================================
+# Standalone model: Integer allocation of scarce DC stock
+# Distributes limited DC units to stores in whole packs so that
+# expected sell-through value is maximized, while every listed
+# store receives at least its minimum display quantity. The
+# display quantity defaults to the planogram's min_pres, the
+# same parameter min_presentation.mod holds stock above, and
+# can be overridden per store in min_disp.
+#
+# Expected sales are concave in the allocated position; they are
+# approximated by demand tranches with decreasing sell probability
+# (marginal-value allocation).
+#
+# Quantities are in eaches; the shipping pack is the order unit
+# of the pack hierarchy in packs.mod (loaded by this file).
+#
+# When stock is short, fair_mode selects the fairness rule:
+#
+#   margin           - expected margin only (no fairness rule)
+#   proportional     - each store's position is at least its
+#                      demand share of total stock (onhand + DC)
+#                      less one pack
+#   priority         - stores are served in rank order: beyond
+#                      its minimum display a store gets stock only
+#                      once every better-ranked store's position
+#                      covers its demand
+#   equal_shortfall  - the largest unit shortfall (demand -
+#                      position) over stores is minimized first,
+#                      margin second
+#
+# allocation.run compares service per mode.
+#
+#   model extensions/allocation.mod;
+#   data extensions/allocation.dat;
+#   solve;  display n;
+# ============================================================
+
+set PROD;                  # products (SKUs) to allocate
+set STORE;                 # receiving stores
+set TR ordered;            # demand tranches, most certain first
+
+# Pack hierarchy; stores receive whole order units
+model extensions/packs.mod;
+
+# -------- Parameters --------
+param dc_stock{PROD} integer >= 0;      # units available at the DC
+param pack{j in PROD} := order_each[j]; # units per shipping pack
+param margin{PROD} >= 0;                # unit margin if sold
+
+param demand{STORE,PROD} >= 0;          # expected demand over the cycle
+param onhand{STORE,PROD} >= 0 default 0;
+param listed{STORE,PROD} binary default 1;
+param min_pres{PROD} >= 0 default 0;         # planogram display units
+param min_disp{st in STORE, j in PROD} >= 0 default min_pres[j];
+
+# Tranche k covers tr_width[k] * demand units, each selling with
+# probability sell_prob[k]; probabilities must be non-increasing.
+param tr_width{TR} >= 0;
+param sell_prob{TR} >= 0, <= 1;
+
+check{k in TR: ord(k) > 1}: sell_prob[k] <= sell_prob[prev(k)];
+
+# Fairness rule
+set FAIR_MODE := {"margin", "proportional", "priority", "equal_shortfall"};
+param fair_mode symbolic in FAIR_MODE default "margin";
+param rank{STORE} integer >= 1 default 1;   # priority, 1 = served first
+param short_pen >= 0 default 1000;          # per unit of max shortfall
+
+param dshare{st in STORE, j in PROD} :=
+    if listed[st,j] = 1 and sum{s2 in STORE} listed[s2,j] * demand[s2,j] > 0
+    then demand[st,j] / sum{s2 in STORE} listed[s2,j] * demand[s2,j]
+    else 0;
+
+# Total stock shared out in proportional mode
+param tot_stock{j in PROD} :=
+    sum{st in STORE} listed[st,j] * onhand[st,j] + dc_stock[j];
+
+# Minimum display quantities, rounded up to whole packs, must be
+# coverable from DC stock
+check{j in PROD}:
+    sum{st in STORE} listed[st,j] * pack[j]
+        * ceil(max(0, min_disp[st,j] - onhand[st,j]) / pack[j]) <= dc_stock[j];
+
+# In proportional mode the packed share targets (or display
+# minimums, or stock already held, if larger) must fit total stock
+check{j in PROD: fair_mode = "proportional"}:
+    sum{st in STORE: listed[st,j] = 1} (onhand[st,j] + pack[j] * ceil(max(0,
+        min_disp[st,j] - onhand[st,j],
+        dshare[st,j] * tot_stock[j] - pack[j] - onhand[st,j]) / pack[j]))
+    <= tot_stock[j];
+
+# -------- Decision Variables --------
+var n{STORE,PROD} integer >= 0;         # packs shipped
+var q{STORE,PROD,TR} >= 0;              # position filling tranche k
+var over{STORE,PROD} >= 0;              # position beyond all tranches
+var filled{STORE,PROD} binary;          # position covers demand (priority)
+var MaxShort{PROD} >= 0;                # largest shortfall (equal_shortfall)
+
+var Pos{st in STORE, j in PROD} = onhand[st,j] + pack[j] * n[st,j];
+
+# -------- Objective --------
+maximize ExpectedMargin:
+    sum{st in STORE, j in PROD, k in TR} margin[j] * sell_prob[k] * q[st,j,k]
+  - (if fair_mode = "equal_shortfall" then short_pen * sum{j in PROD} MaxShort[j] else 0);
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Cannot ship more than the DC holds
+subject to DCStock{j in PROD}:
+    sum{st in STORE} pack[j] * n[st,j] <= dc_stock[j];
+
+# 2) Only listed stores receive stock
+subject to ListedOnly{st in STORE, j in PROD: listed[st,j] = 0}:
+    n[st,j] = 0;
+
+# 3) Store position is split across the demand tranches
+subject to Position{st in STORE, j in PROD}:
+    onhand[st,j] + pack[j] * n[st,j] = sum{k in TR} q[st,j,k] + over[st,j];
+
+subject to TrancheCap{st in STORE, j in PROD, k in TR}:
+    q[st,j,k] <= tr_width[k] * demand[st,j];
+
+# 4) Minimum display quantity for listed products
+subject to MinDisplay{st in STORE, j in PROD: listed[st,j] = 1}:
+    onhand[st,j] + pack[j] * n[st,j] >= min_disp[st,j];
+
+# 5) Fairness rules (active for the selected fair_mode only)
+subject to PropShare{st in STORE, j in PROD:
+        fair_mode = "proportional" and listed[st,j] = 1}:
+    Pos[st,j] >= dshare[st,j] * tot_stock[j] - pack[j];
+
+subject to FilledOff{st in STORE, j in PROD: fair_mode <> "priority"}:
+    filled[st,j] = 0;
+
+subject to PriorityFilled{st in STORE, j in PROD:
+        fair_mode = "priority" and listed[st,j] = 1}:
+    Pos[st,j] >= demand[st,j] * filled[st,j];
+
+subject to PriorityOrder{st in STORE, s2 in STORE, j in PROD:
+        fair_mode = "priority" and rank[st] < rank[s2]
+        and listed[st,j] = 1 and listed[s2,j] = 1}:
+    n[s2,j] <= ceil(max(0, min_disp[s2,j] - onhand[s2,j]) / pack[j])
+             + floor(dc_stock[j] / pack[j]) * filled[st,j];
+
+subject to EqualShortfall{st in STORE, j in PROD:
+        fair_mode = "equal_shortfall" and listed[st,j] = 1}:
+    demand[st,j] - Pos[st,j] <= MaxShort[j];
@@ -0,0 +1,99 @@
+# ============================================================
+# APO-1 extension: Adaptive Large Neighborhood Search (ALNS)
+# Heuristic for large joint assortment + space + price instances.
+# It stacks on APO-1 with shelf_space.mod, which must be loaded
+# first, and adds facings per product and a price tier per
+# product to the decisions. Each iteration a destroy operator
+# frees part of the plan and the repair step re-solves APO-1
+# with everything else fixed at the incumbent:
+#
+#   random, worst, related - free whole products (listing,
+#                            facings and tier); worst ranks them
+#                            by their contribution in the
+#                            incumbent, related takes products
+#                            sharing a random product's fixture
+#   reprice                - free the price tiers of all products
+#   respace                - free the facings on a random fixture
+#
+# Simulated-annealing acceptance decides whether to move, and
+# operator weights adapt to how often each operator finds
+# better solutions. Runs until the iteration limit or the time
+# budget is reached.
+#
+# Driven by extensions/alns.run.
+# ============================================================
+
+# Destroy operators
+set OP := {"random", "worst", "related", "reprice", "respace"};
+
+param alns_iters integer > 0 default 200;
+param time_budget > 0 default 300;            # seconds
+param n_free integer >= 1 default 2;          # products freed per destroy
+param temp0 >= 0 default 50;                  # initial SA temperature
+param cooling > 0, < 1 default 0.97;
+param rho >= 0, <= 1 default 0.2;             # weight reaction factor
+param score_best default 3;                   # new global best
+param score_better default 2;                 # better than current
+param score_accept default 1;                 # accepted, not better
+
+# -------- Facings and price tiers --------
+set PRICE_TIER ordered;                       # price tiers
+param tier_mult{PRICE_TIER} > 0, <= 1;        # tier price as share of p_ub
+param max_face integer >= 1 default 4;        # facings per listed product
+param face_width{j in PROD} >= 0 default width[j];   # width per facing
+param face_cap{PROD} > 0;                     # units a facing sells per period
+
+var nf{PROD} integer >= 0, <= max_face;       # facings
+var tier_on{PROD,PRICE_TIER} binary;          # price tier chosen
+
+# A listed product gets at least one facing, an unlisted one none
+subject to FacingsListed{j in PROD}:
+    nf[j] >= z[j];
+
+subject to FacingsMax{j in PROD}:
+    nf[j] <= max_face * z[j];
+
+# Facings replace the one-slot width of shelf_space.mod
+subject to ShelfFacings{fx in FIXTURE}:
+    sum{j in PROD: fixture_of[j] = fx} face_width[j] * nf[j] <= space[fx];
+
+# Sales per period are limited by what the facings hold
+subject to FacingSales{j in PROD, t in PER}:
+    d[j,t] <= face_cap[j] * nf[j];
+
+# One price tier per listed product, for the whole horizon
+subject to OnePriceTier{j in PROD}:
+    sum{k in PRICE_TIER} tier_on[j,k] = z[j];
+
+subject to TierPrice{j in PROD, t in PER}:
+    p[j,t] = sum{k in PRICE_TIER} tier_mult[k] * p_ub[j,t] * tier_on[j,k];
+
+drop ShelfSpace;
+
+# -------- Search state --------
+param z_cur{PROD} binary default 0;           # incumbent
+param nf_cur{PROD} integer >= 0 default 0;
+param tier_cur{PROD} symbolic in PRICE_TIER default first(PRICE_TIER);
+param contrib_cur{PROD} default 0;            # contribution of j in the incumbent
+param f_cur default -Infinity;
+
+param z_elite{PROD} binary default 0;         # best found
+param nf_elite{PROD} integer >= 0 default 0;
+param tier_elite{PROD} symbolic in PRICE_TIER default first(PRICE_TIER);
+param f_best default -Infinity;
+
+param f_new;
+param temp;
+param t_start;
+
+param op_wt{OP} > 0 default 1;
+param op_score{OP} default 0;
+param op_uses{OP} default 0;
+param op symbolic in OP default "random";
+param pick;
+
+param key{PROD};                              # random sort keys
+param seed_fix symbolic in FIXTURE;           # fixture of the related seed
+param free{PROD} binary default 0;            # listing, facings and tier free
+param free_face{PROD} binary default 0;       # facings free
+param free_tier{PROD} binary default 0;       # price tier free
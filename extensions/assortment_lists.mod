@@ -0,0 +1,34 @@
+# ============================================================
+# APO-1 extension: Mandatory and banned assortment lists
+# Lists are kept per cluster and per store; a run plans one
+# store, which inherits its cluster's lists. Mandatory products
+# must be carried, banned products must not (local regulation,
+# franchise agreements). assortment_lists.run reports the
+# profit impact of the restrictions.
+#
+#   model APO-1.mod;  model extensions/assortment_lists.mod;
+#   data "Sample 2.dat";  data extensions/assortment_lists.dat;
+#   include extensions/assortment_lists.run;
+# ============================================================
+
+set CLUSTER;
+set STORE;
+
+param cluster_of{STORE} symbolic in CLUSTER;
+param store symbolic in STORE;             # store being planned
+
+set MAND_CLUSTER{CLUSTER} within PROD default {};
+set BAN_CLUSTER{CLUSTER}  within PROD default {};
+set MAND_STORE{STORE}     within PROD default {};
+set BAN_STORE{STORE}      within PROD default {};
+
+set MANDATORY := MAND_CLUSTER[cluster_of[store]] union MAND_STORE[store];
+set BANNED    := BAN_CLUSTER[cluster_of[store]]  union BAN_STORE[store];
+
+check: card(MANDATORY inter BANNED) = 0;
+
+subject to Mandatory{j in MANDATORY}:
+    z[j] = 1;
+
+subject to Banned{j in BANNED}:
+    z[j] = 0;
@@ -0,0 +1,85 @@
+# ============================================================
+# Standalone model: Bass diffusion forecast for new categories
+# Cumulative adopters follow
+#
+#   N(t) = m * (1 - exp(-(p+q) t)) / (1 + (q/p) exp(-(p+q) t))
+#
+# Priors for innovation p, imitation q and market size m come
+# from comparable launches. As early sales arrive, the fit trades
+# squared error on observed period sales against the distance
+# to the priors (MAP-style update); with no observations the
+# forecast is the analog average. Solve with a nonlinear solver.
+#
+#   model extensions/bass_diffusion.mod;
+#   data extensions/bass_diffusion.dat;
+#   solve;  display bp, bq, bm, fc_sales;
+#
+# bass_diffusion_drift.run refits only when the last saved fit
+# (bd_fit_file) has gone stale: its forecast is biased by more
+# than bd_bias_max on the periods observed since, or there is no
+# saved fit. After a refit it flags parameters that moved more
+# than bd_drift_z prior standard deviations.
+# ============================================================
+
+set ANALOG;                                  # comparable launches
+
+param a_p{ANALOG} > 0;
+param a_q{ANALOG} >= 0;
+param a_m{ANALOG} > 0;
+param scale{ANALOG} > 0 default 1;           # market size ratio to ours
+
+param n_obs integer >= 0 default 0;          # periods with sales so far
+param horizon integer >= 1;                  # forecast periods
+param obs_sales{1..n_obs} >= 0;
+
+param prior_wt >= 0 default 1;               # weight of the analog priors
+
+# -------- Priors from the analogs --------
+param p_prior := sum{a in ANALOG} a_p[a] / card(ANALOG);
+param q_prior := sum{a in ANALOG} a_q[a] / card(ANALOG);
+param m_prior := sum{a in ANALOG} a_m[a] * scale[a] / card(ANALOG);
+
+param p_sd := max(0.1 * p_prior, sqrt(sum{a in ANALOG} (a_p[a] - p_prior)^2 / card(ANALOG)));
+param q_sd := max(0.1 * q_prior, sqrt(sum{a in ANALOG} (a_q[a] - q_prior)^2 / card(ANALOG)));
+param m_sd := max(0.1 * m_prior,
+    sqrt(sum{a in ANALOG} (a_m[a] * scale[a] - m_prior)^2 / card(ANALOG)));
+
+# -------- Decision Variables --------
+var bp >= 1e-5, <= 1, := p_prior;
+var bq >= 0,    <= 2, := q_prior;
+var bm >= 1,          := m_prior;
+
+var Ncum{t in 0..horizon} =
+    bm * (1 - exp(-(bp + bq) * t)) / (1 + (bq / bp) * exp(-(bp + bq) * t));
+
+var fc_sales{t in 1..horizon} = Ncum[t] - Ncum[t-1];
+
+# -------- Objective --------
+minimize FitError:
+    sum{t in 1..n_obs} (fc_sales[t] - obs_sales[t])^2 / max(1, m_prior)
+  + prior_wt * ( ((bp - p_prior) / p_sd)^2
+               + ((bq - q_prior) / q_sd)^2
+               + ((bm - m_prior) / m_sd)^2 );
+
+check: n_obs <= horizon;
+
+# -------- Drift against the last saved fit (bass_diffusion_drift.run) --------
+param bd_fit_file symbolic default "bass_fit.dat";
+param bd_prev_p > 0 default 1e-5;
+param bd_prev_q >= 0 default 0;
+param bd_prev_m > 0 default 1;
+param bd_prev_obs integer >= 0 default 0;   # periods seen at that fit, 0 = none
+param bd_bias_max > 0 default 0.2;
+param bd_drift_z > 0 default 2;
+
+param bd_prev_N{t in 0..horizon} :=
+    bd_prev_m * (1 - exp(-(bd_prev_p + bd_prev_q) * t))
+  / (1 + (bd_prev_q / bd_prev_p) * exp(-(bd_prev_p + bd_prev_q) * t));
+param bd_prev_fc{t in 1..horizon} := bd_prev_N[t] - bd_prev_N[t-1];
+
+set BD_NEW := {t in 1..n_obs: t > bd_prev_obs};  # observed since the fit
+param bd_bias :=
+    if sum{t in BD_NEW} obs_sales[t] > 0
+    then sum{t in BD_NEW} (obs_sales[t] - bd_prev_fc[t]) / sum{t in BD_NEW} obs_sales[t]
+    else 0;
+param bd_refit binary default 0;
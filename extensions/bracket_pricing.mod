@@ -0,0 +1,63 @@
+# ============================================================
+# APO-1 extension: MOQ tiers and vendor bracket pricing
+# Unit cost depends on the order size bracket (all-units
+# discount). The lowest bracket minimum acts as the vendor MOQ.
+# Ordering into a higher bracket than demand needs is allowed:
+# the extra units are carried (at h) and sold later, so the
+# model rounds up exactly when the discount beats the carrying
+# cost. Bracket minimums are quoted in order units (packs.mod)
+# and converted to eaches.
+#
+#   model APO-1.mod;  model extensions/packs.mod;
+#   model extensions/bracket_pricing.mod;
+#   data "Sample 2.dat";  data extensions/packs.dat;
+#   data extensions/bracket_pricing.dat;
+#   solve;  display v, uq;
+# ============================================================
+
+set TIER ordered;                          # brackets, smallest first
+
+param tier_min{PROD,TIER} >= 0;            # bracket start, order units
+param tier_disc{PROD,TIER} >= 0, < 1 default 0;  # discount on c[j,t]
+
+check{j in PROD, k in TIER: ord(k) > 1}: tier_min[j,k] > tier_min[j,prev(k)];
+
+# Bracket upper end; the last one is bounded by OrderCap's bound
+param tier_max{j in PROD, t in PER, k in TIER} :=
+    if k = last(TIER) then (card(PER) - ord(t) + 1) * S_total
+    else order_each[j] * tier_min[j,next(k)];
+
+# -------- Decision Variables --------
+var v{PROD,PER,TIER} binary;               # bracket chosen for the order
+var uq{PROD,PER,TIER} >= 0;                # order quantity in that bracket
+
+# -------- Objective --------
+maximize Profit_Tiered:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - sum{k in TIER} c[j,t] * (1 - tier_disc[j,k]) * uq[j,t,k]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) One bracket per placed order
+subject to OneTier{j in PROD, t in PER}:
+    sum{k in TIER} v[j,t,k] = y[j,t];
+
+# 2) Quantity lies inside the chosen bracket (first bracket = MOQ)
+subject to TierLow{j in PROD, t in PER, k in TIER}:
+    uq[j,t,k] >= order_each[j] * tier_min[j,k] * v[j,t,k];
+
+subject to TierHigh{j in PROD, t in PER, k in TIER}:
+    uq[j,t,k] <= tier_max[j,t,k] * v[j,t,k];
+
+# 3) Link to APO-1's order quantity
+subject to TierQty{j in PROD, t in PER}:
+    u[j,t] = sum{k in TIER} uq[j,t,k];
+
+objective Profit_Tiered;
@@ -0,0 +1,74 @@
+# ============================================================
+# APO-1 extension: Private-label vs national-brand price gap
+# Each pair (nb, pl) in BRAND_PAIR keeps the private label
+# priced a gap below its national brand, as a share of the NB
+# price, whenever both are listed. Inside the band the choice
+# model picks the gap that maximizes combined category profit.
+#
+# gap_elast is the historical gap elasticity of each pair: the
+# OLS slope of PL unit share on the gap, from past periods
+# (share points per gap point). It turns the category's PL share
+# band [pl_share_min, pl_share_max] into a gap band around the
+# historical mean,
+#
+#   gap = mean_gap + (share - mean_share) / gap_elast,
+#
+# intersected with the policy band [gap_min, gap_max]; without a
+# usable (positive) elasticity the policy band applies alone.
+# brand_gap.run sweeps the gap across the band and shows the
+# model's PL share next to the fitted one.
+#
+#   model APO-1.mod;  model extensions/brand_gap.mod;
+#   data "Sample 2.dat";  data extensions/brand_gap.dat;
+#   solve;  display {(n,l) in BRAND_PAIR, t in PER} p[l,t] / p[n,t];
+# ============================================================
+
+set BRAND_PAIR within {PROD,PROD};            # (national brand, private label)
+
+param gap_min{BRAND_PAIR} >= 0, < 1 default 0.10;
+param gap_max{(n,l) in BRAND_PAIR} >= gap_min[n,l], < 1 default 0.35;
+param gap_fix{BRAND_PAIR} default -1;         # >= 0 pins the gap (brand_gap.run)
+param gap_steps integer >= 1 default 5;       # sweep resolution
+
+# -------- Historical gap elasticity --------
+set GHIST ordered default {};
+param hist_gap{BRAND_PAIR,GHIST};             # observed gap
+param hist_share{BRAND_PAIR,GHIST};           # observed PL unit share
+
+param n_gh := card(GHIST);
+param mean_gap{(n,l) in BRAND_PAIR} :=
+    if n_gh = 0 then 0 else sum{h in GHIST} hist_gap[n,l,h] / n_gh;
+param mean_share{(n,l) in BRAND_PAIR} :=
+    if n_gh = 0 then 0 else sum{h in GHIST} hist_share[n,l,h] / n_gh;
+param gap_elast{(n,l) in BRAND_PAIR} :=
+    if n_gh < 2 or sum{h in GHIST} (hist_gap[n,l,h] - mean_gap[n,l])^2 = 0 then 0
+    else sum{h in GHIST} (hist_gap[n,l,h] - mean_gap[n,l])
+                       * (hist_share[n,l,h] - mean_share[n,l])
+       / sum{h in GHIST} (hist_gap[n,l,h] - mean_gap[n,l])^2;
+
+# PL share band translated into a gap band
+param pl_share_min{BRAND_PAIR} >= 0, <= 1 default 0;
+param pl_share_max{(n,l) in BRAND_PAIR} >= pl_share_min[n,l], <= 1 default 1;
+
+param gap_lo{(n,l) in BRAND_PAIR} :=
+    if gap_elast[n,l] <= 0 then gap_min[n,l]
+    else min(gap_max[n,l], max(gap_min[n,l],
+             mean_gap[n,l] + (pl_share_min[n,l] - mean_share[n,l]) / gap_elast[n,l]));
+
+param gap_hi{(n,l) in BRAND_PAIR} :=
+    if gap_elast[n,l] <= 0 then gap_max[n,l]
+    else max(gap_lo[n,l], min(gap_max[n,l],
+             mean_gap[n,l] + (pl_share_max[n,l] - mean_share[n,l]) / gap_elast[n,l]));
+
+# ============================================================
+# Constraints (gap as a share of the NB price, both listed)
+# ============================================================
+
+subject to PLGapLow{(n,l) in BRAND_PAIR, t in PER}:
+    p[l,t] <= (1 - gap_lo[n,l]) * p[n,t] + p_ub[l,t] * (2 - z[n] - z[l]);
+
+subject to PLGapHigh{(n,l) in BRAND_PAIR, t in PER}:
+    p[l,t] >= (1 - gap_hi[n,l]) * p[n,t] - p_ub[n,t] * (2 - z[n] - z[l]);
+
+subject to PLGapFixed{(n,l) in BRAND_PAIR, t in PER: gap_fix[n,l] >= 0}:
+    p[l,t] = (1 - gap_fix[n,l]) * p[n,t];
@@ -0,0 +1,24 @@
+# ============================================================
+# APO-1 extension: Assortment breadth vs depth frontier
+# Caps the number of listed SKUs at max_sku. breadth_depth.run
+# sweeps the cap from 0 to card(PROD) and reports, for each
+# breadth, the optimal profit, the marginal profit of the last
+# SKU added, and the inventory it takes (units bought, average
+# stock per listed SKU), so diminishing returns show directly.
+#
+#   ampl extensions/breadth_depth.run
+# ============================================================
+
+param max_sku integer >= 0 default card(PROD);
+
+set BREADTH := 0..card(PROD);
+
+# -------- Filled by breadth_depth.run --------
+param bd_profit{BREADTH} default -Infinity;
+param bd_listed{BREADTH} default 0;
+param bd_bought{BREADTH} default 0;        # units ordered over the horizon
+param bd_stock{BREADTH} default 0;         # average end-of-period stock
+param bd_units{BREADTH} default 0;         # units sold
+
+subject to Breadth:
+    sum{j in PROD} z[j] <= max_sku;
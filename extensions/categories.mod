@@ -0,0 +1,16 @@
+# ============================================================
+# APO-1 extension: Merchandise categories (shared)
+# Declares the category of every product once, for all category-
+# level extensions (open_to_buy, fresh_waste, category_roles,
+# wos_targets). Load it after APO-1.mod and before any of them,
+# so that several can be stacked on the same categories.
+#
+#   model APO-1.mod;  model extensions/categories.mod;
+#   model extensions/wos_targets.mod;
+#   data "Sample 2.dat";  data extensions/categories.dat;
+#   data extensions/wos_targets.dat;
+# ============================================================
+
+set CAT;                                  # merchandise categories
+
+param cat_of{PROD} symbolic in CAT;
@@ -0,0 +1,68 @@
+# ============================================================
+# APO-1 extension: Category roles
+# Every category is tagged with a role and each role brings its
+# own objective weights and rules, applied to all categories in
+# a chain-wide run:
+#
+#   traffic - units weigh in, prices held to a competitor index
+#   margin  - margin only
+#   basket  - margin plus units, with a minimum listed breadth
+#
+# The objective is the APO-1 profit per product scaled by its
+# role's margin weight, plus unit_val per unit sold scaled by
+# the role's unit weight.
+#
+# Categories come from categories.mod:
+#   model APO-1.mod;  model extensions/categories.mod;
+#   model extensions/category_roles.mod;
+#   data "Sample 2.dat";  data extensions/categories.dat;
+#   data extensions/category_roles.dat;
+#   solve;  display Role_Margin, Role_Units;
+# ============================================================
+
+set ROLE := {"traffic", "margin", "basket"};
+
+param role_of{CAT} symbolic in ROLE;
+
+param wt_margin{ROLE} >= 0 default 1;
+param wt_units{ROLE} >= 0 default 0;
+param unit_val >= 0 default 0.1;              # value of one unit sold
+
+param idx_cap{ROLE} > 0 default Infinity;     # max price / competitor
+param min_breadth{ROLE} >= 0, <= 1 default 0; # share of category listed
+
+param role_comp{PROD,PER} >= 0 default 0;     # competitor price (0 = none)
+
+param role{j in PROD} symbolic := role_of[cat_of[j]];
+
+# -------- Per-product contribution (APO-1 profit terms) --------
+var Contrib{j in PROD} =
+    sum{t in PER} (sum{i in SEG} s[i] * g[i,j,t]
+                   - K[j,t] * y[j,t] - c[j,t] * u[j,t] - h[j,t] * I[j,t])
+  - f[j] * z[j];
+
+var Role_Margin{r in ROLE} = sum{j in PROD: role[j] = r} Contrib[j];
+var Role_Units{r in ROLE} = sum{j in PROD, t in PER: role[j] = r} d[j,t];
+
+# ============================================================
+# Objective: role-weighted profit and units
+# ============================================================
+maximize Profit_Roles:
+    sum{j in PROD} (wt_margin[role[j]] * Contrib[j]
+                    + wt_units[role[j]] * unit_val * sum{t in PER} d[j,t]);
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Price index cap (traffic drivers by default)
+subject to RoleIndex{j in PROD, t in PER:
+        idx_cap[role[j]] < Infinity and role_comp[j,t] > 0}:
+    p[j,t] <= idx_cap[role[j]] * role_comp[j,t];
+
+# 2) Minimum breadth of listed items per category
+subject to RoleBreadth{k in CAT: min_breadth[role_of[k]] > 0}:
+    sum{j in PROD: cat_of[j] = k} z[j]
+    >= ceil(min_breadth[role_of[k]] * card({j in PROD: cat_of[j] = k}));
+
+objective Profit_Roles;
@@ -0,0 +1,72 @@
+# ============================================================
+# APO-1 extension: Store / online channel pricing
+# Every segment shops in one channel and sees that channel's
+# price; channels have their own fulfillment cost per unit and
+# draw on the same inventory. Products flagged omni[j] must
+# carry one price across channels; the others may be priced per
+# channel. Channel-specific price response comes from the
+# segments' own reservation prices.
+#
+# Replaces APO-1's price linearization and choice constraints
+# with channel-indexed versions.
+#
+#   model APO-1.mod;  model extensions/channels.mod;
+#   data "Sample 2.dat";  data extensions/channels.dat;
+#   solve;  display pc;
+# ============================================================
+
+set CH;                                   # sales channels
+
+param ch_of{SEG} symbolic in CH;          # channel a segment shops in
+param ful_cost{CH,PROD} >= 0 default 0;   # fulfillment cost per unit
+param omni{PROD} binary default 1;        # 1 = single price across channels
+
+# -------- Decision Variables --------
+var pc{CH,PROD,PER} >= 0;                 # channel price
+var wc{CH,PROD,PER} >= 0;                 # wc = pc * z
+
+# -------- Objective --------
+maximize Profit_Channels:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * (g[i,j,t] - ful_cost[ch_of[i],j] * x[i,j,t])
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Channel prices only for listed products; omni products share p
+subject to PriceUpper_Ch{ch in CH, j in PROD, t in PER}:
+    pc[ch,j,t] <= p_ub[j,t] * z[j];
+
+subject to OmniPrice{ch in CH, j in PROD, t in PER: omni[j] = 1}:
+    pc[ch,j,t] = p[j,t];
+
+# 2) g[i,j,t] = pc[ch_of[i],j,t] * x[i,j,t]
+subject to g_up2_Ch{i in SEG, j in PROD, t in PER}:
+    g[i,j,t] <= pc[ch_of[i],j,t];
+
+subject to g_low_Ch{i in SEG, j in PROD, t in PER}:
+    g[i,j,t] >= pc[ch_of[i],j,t] - p_ub[j,t] * (1 - x[i,j,t]);
+
+# 3) wc[ch,j,t] = pc[ch,j,t] * z[j]
+subject to w_up1_Ch{ch in CH, j in PROD, t in PER}:
+    wc[ch,j,t] <= p_ub[j,t] * z[j];
+
+subject to w_up2_Ch{ch in CH, j in PROD, t in PER}:
+    wc[ch,j,t] <= pc[ch,j,t];
+
+subject to w_low_Ch{ch in CH, j in PROD, t in PER}:
+    wc[ch,j,t] >= pc[ch,j,t] - p_ub[j,t] * (1 - z[j]);
+
+# 4) Max-surplus choice at the segment's channel prices
+subject to UtilityChoice_Ch{i in SEG, t in PER, j in PROD}:
+    sum{k in PROD} alpha[i,k,t] * x[i,k,t] - sum{k in PROD} g[i,k,t]
+    >= alpha[i,j,t] * z[j] - wc[ch_of[i],j,t];
+
+drop g_up2;  drop g_low;  drop UtilityChoice;
+objective Profit_Channels;
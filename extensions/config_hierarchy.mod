@@ -0,0 +1,55 @@
+# ============================================================
+# APO-1 extension: Hierarchical settings (chain/region/store/SKU)
+# Every setting has a chain-wide value and optional overrides
+# per region, per store and per product; the most specific
+# level that is set wins. cfg[k,j] is the value that applies to
+# product j in the planned store and cfg_src[k,j] tells which
+# level it came from (config_hierarchy.run prints both).
+#
+# Applied here: max_price_change caps the relative price move
+# between consecutive periods. service_level is the per-product
+# service target of safety_stock.mod (service_j), which must be
+# loaded first; config_hierarchy.run hands the resolved values
+# over before the solve.
+#
+#   model APO-1.mod;  model extensions/safety_stock.mod;
+#   model extensions/config_hierarchy.mod;
+#   data "Sample 2.dat";  data extensions/safety_stock.dat;
+#   data extensions/config_hierarchy.dat;
+#   include extensions/config_hierarchy.run;  solve;
+# ============================================================
+
+set SETTING := {"service_level", "max_price_change"};
+set CFG_REGION;
+set CFG_STORE;
+
+param region_of{CFG_STORE} symbolic in CFG_REGION;
+param plan_store symbolic in CFG_STORE;        # store being planned
+
+# Unset overrides are marked with a negative value
+param cfg_chain{SETTING} >= 0;
+param cfg_region{SETTING,CFG_REGION} default -1;
+param cfg_store{SETTING,CFG_STORE} default -1;
+param cfg_sku{SETTING,PROD} default -1;
+
+param plan_region symbolic := region_of[plan_store];
+
+# -------- Resolution: SKU > store > region > chain --------
+param cfg{k in SETTING, j in PROD} :=
+    if cfg_sku[k,j] >= 0 then cfg_sku[k,j]
+    else if cfg_store[k,plan_store] >= 0 then cfg_store[k,plan_store]
+    else if cfg_region[k,plan_region] >= 0 then cfg_region[k,plan_region]
+    else cfg_chain[k];
+
+param cfg_src{k in SETTING, j in PROD} symbolic :=
+    if cfg_sku[k,j] >= 0 then "sku"
+    else if cfg_store[k,plan_store] >= 0 then "store " & plan_store
+    else if cfg_region[k,plan_region] >= 0 then "region " & plan_region
+    else "chain";
+
+# -------- Applied settings --------
+subject to MaxPriceUp{j in PROD, t in PER: ord(t) > 1}:
+    p[j,t] <= (1 + cfg["max_price_change",j]) * p[j,prev(t)] + p_ub[j,t] * (1 - z[j]);
+
+subject to MaxPriceDown{j in PROD, t in PER: ord(t) > 1}:
+    p[j,t] >= (1 - cfg["max_price_change",j]) * p[j,prev(t)];
@@ -0,0 +1,55 @@
+# ============================================================
+# APO-1 extension: Consolidated container ordering
+# Import products ship from an origin in containers limited by
+# cube and weight and charged per container. Orders of all
+# products from the same origin share containers, so the model
+# pulls orders forward or delays them to fill containers. An
+# optional minimum fill rules out part-empty containers. Cube
+# and weight per unit default to the each values of packs.mod.
+#
+#   model APO-1.mod;  model extensions/packs.mod;
+#   model extensions/containers.mod;
+#   data "Sample 2.dat";  data extensions/packs.dat;
+#   data extensions/containers.dat;
+#   solve;  display boxes;
+# ============================================================
+
+set ORIGIN;
+set IMPORT within PROD;                        # products shipped by container
+
+param origin_of{IMPORT} symbolic in ORIGIN;
+param unit_cube{j in IMPORT} > 0 default cube_each[j];
+param unit_wt{j in IMPORT} > 0 default wt_each[j];
+
+param box_cube > 0;                            # container cube
+param box_wt > 0;                              # container payload
+param box_cost{ORIGIN,PER} >= 0;               # freight per container
+param min_fill >= 0, <= 1 default 0;           # min cube utilization
+
+var boxes{ORIGIN,PER} integer >= 0;
+
+# -------- Objective --------
+maximize Profit_Freight:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j]
+  - sum{o in ORIGIN, t in PER} box_cost[o,t] * boxes[o,t];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to BoxCube{o in ORIGIN, t in PER}:
+    sum{j in IMPORT: origin_of[j] = o} unit_cube[j] * u[j,t] <= box_cube * boxes[o,t];
+
+subject to BoxWeight{o in ORIGIN, t in PER}:
+    sum{j in IMPORT: origin_of[j] = o} unit_wt[j] * u[j,t] <= box_wt * boxes[o,t];
+
+subject to BoxFill{o in ORIGIN, t in PER: min_fill > 0}:
+    sum{j in IMPORT: origin_of[j] = o} unit_cube[j] * u[j,t] >= min_fill * box_cube * boxes[o,t];
+
+objective Profit_Freight;
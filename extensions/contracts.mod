@@ -0,0 +1,36 @@
+# ============================================================
+# APO-1 extension: Vendor contract constraints
+# Contract terms are kept as data and turned into constraints:
+#   - minimum purchase commitment (at cost) over a window
+#   - exclusivity: competing products may not be sold while a
+#     contract's exclusivity window is active
+#   - price protection: a minimum advertised price for the
+#     contract's products during the window
+#
+#   model APO-1.mod;  model extensions/contracts.mod;
+#   data "Sample 2.dat";  data extensions/contracts.dat;
+#   solve;
+# ============================================================
+
+set CONTRACT;
+
+set CPROD{CONTRACT} within PROD;                 # products covered
+set WINDOW{CONTRACT} within PER;                 # periods the terms apply
+
+param commit{CONTRACT} >= 0 default 0;           # min spend at cost
+set EXCLUDED{CONTRACT} within PROD default {};   # blocked by exclusivity
+param map_price{k in CONTRACT, j in CPROD[k]} >= 0 default 0;  # price floor
+
+check{k in CONTRACT}: card(CPROD[k] inter EXCLUDED[k]) = 0;
+
+# 1) Minimum purchase commitment
+subject to Commitment{k in CONTRACT: commit[k] > 0}:
+    sum{j in CPROD[k], t in WINDOW[k]} c[j,t] * u[j,t] >= commit[k];
+
+# 2) Exclusivity: no sales of competing products inside the window
+subject to Exclusivity{k in CONTRACT, j in EXCLUDED[k], i in SEG, t in WINDOW[k]}:
+    x[i,j,t] = 0;
+
+# 3) Price protection: listed products stay at or above the floor
+subject to PriceProtection{k in CONTRACT, j in CPROD[k], t in WINDOW[k]: map_price[k,j] > 0}:
+    p[j,t] >= map_price[k,j] * z[j];
@@ -0,0 +1,64 @@
+# ============================================================
+# APO-1 extension: Counterfactual demand queries
+# Answers batches of "what would demand for SKU j have been at
+# price p in periods from..to?" against the choice model, with
+# every other price at its actual value (act_price, 0 = not
+# offered). A segment buys the offered product with the largest
+# non-negative surplus alpha - price; ties split evenly. No
+# solve is needed, so thousands of queries evaluate directly.
+#
+# counterfactual.run adds uncertainty by redrawing alpha with
+# standard error cf_alpha_sd and reports mean, sd and an
+# interval per query. Results can be written to cf_out_file as
+# CSV. Each include starts a fresh batch.
+#
+#   model APO-1.mod;  model extensions/counterfactual.mod;
+#   data "Sample 2.dat";  data extensions/counterfactual.dat;
+#   include extensions/counterfactual.run;
+# ============================================================
+
+set QUERY;
+param q_prod{QUERY} symbolic in PROD;
+param q_price{QUERY} >= 0;
+param q_from{QUERY} symbolic in PER;
+param q_to{k in QUERY} symbolic in PER;
+
+check{k in QUERY}: ord(q_from[k], PER) <= ord(q_to[k], PER);
+
+param act_price{PROD,PER} >= 0;                 # actual prices
+param cf_alpha_sd{SEG,PROD,PER} >= 0 default 0;
+
+param cf_n_draw integer >= 2 default 200;
+param z_crit > 0 default 1.96;
+param cf_out_file symbolic default "";
+
+set QPER{k in QUERY} := {t in PER: ord(t) >= ord(q_from[k], PER) and ord(t) <= ord(q_to[k], PER)};
+
+# Price of j in t under query k
+param qp{k in QUERY, j in PROD, t in QPER[k]} :=
+    if j = q_prod[k] then q_price[k] else act_price[j,t];
+
+set OFFER{k in QUERY, t in QPER[k]} := {j in PROD: j = q_prod[k] or act_price[j,t] > 0};
+
+param sur{k in QUERY, t in QPER[k], i in SEG, j in OFFER[k,t]} := alpha[i,j,t] - qp[k,j,t];
+
+param best{k in QUERY, t in QPER[k], i in SEG} :=
+    max(0, max{j in OFFER[k,t]} sur[k,t,i,j]);
+
+param n_best{k in QUERY, t in QPER[k], i in SEG} :=
+    card({j in OFFER[k,t]: sur[k,t,i,j] >= 0 and sur[k,t,i,j] = best[k,t,i]});
+
+# Units of the queried SKU over the query window
+param q_units{k in QUERY} :=
+    sum{t in QPER[k], i in SEG:
+        n_best[k,t,i] > 0 and sur[k,t,i,q_prod[k]] = best[k,t,i]}
+        s[i] / n_best[k,t,i];
+
+# -------- Filled by counterfactual.run --------
+param cf_alpha0{SEG,CHOICE,PER};               # alpha before the redraws
+param cf_point{QUERY};                         # estimate at alpha0
+param q_sum{QUERY} default 0;
+param q_sq{QUERY} default 0;
+param q_mean{k in QUERY} := q_sum[k] / cf_n_draw;
+param q_sd{k in QUERY} :=
+    sqrt(max(0, q_sq[k] / cf_n_draw - q_mean[k]^2) * cf_n_draw / (cf_n_draw - 1));
@@ -0,0 +1,44 @@
+# ============================================================
+# APO-1 extension: Data quality gate
+# Validates the loaded data before solving. Each rule has a mode:
+#   "fail"       - the solve stops (AMPL check failure)
+#   "quarantine" - offending products are excluded (z = 0)
+#   "warn"       - reported only
+# Type errors and duplicate keys are already rejected by AMPL
+# while reading the data; these rules catch values that are
+# legal but implausible. data_quality.run prints the report.
+#
+#   model APO-1.mod;  model extensions/data_quality.mod;
+#   data "Sample 2.dat";  data extensions/data_quality.dat;
+#   include extensions/data_quality.run;  solve;
+# ============================================================
+
+set RULE := {"NOPURCH_WTP", "COST_OVER_PRICE", "PUB_BELOW_WTP",
+             "NO_WTP", "NO_COST"};
+
+param mode{RULE} symbolic in {"fail", "quarantine", "warn"} default "warn";
+param cost_ratio_max > 0 default 10;      # c may not exceed this x p_ub
+
+# -------- Rule evaluation: viol[r,j] = 1 if product j breaks rule r --------
+param viol{r in RULE, j in PROD} binary :=
+    if r = "NOPURCH_WTP" then
+        # alpha[i,0,t] must be 0 (reported against every product)
+        (if exists{i in SEG, t in PER} alpha[i,0,t] <> 0 then 1 else 0)
+    else if r = "COST_OVER_PRICE" then
+        (if exists{t in PER} c[j,t] > cost_ratio_max * p_ub[j,t] then 1 else 0)
+    else if r = "PUB_BELOW_WTP" then
+        (if exists{t in PER} p_ub[j,t] < max{i in SEG} alpha[i,j,t] - 1e-9 then 1 else 0)
+    else if r = "NO_WTP" then
+        (if forall{i in SEG, t in PER} alpha[i,j,t] = 0 then 1 else 0)
+    else
+        (if forall{t in PER} c[j,t] = 0 then 1 else 0);
+
+param n_viol{r in RULE} := sum{j in PROD} viol[r,j];
+
+set QUARANTINE := {j in PROD: exists{r in RULE: mode[r] = "quarantine"} viol[r,j] = 1};
+
+# Stop on any violation of a "fail" rule
+check: sum{r in RULE: mode[r] = "fail"} n_viol[r] = 0;
+
+subject to Quarantined{j in QUARANTINE}:
+    z[j] = 0;
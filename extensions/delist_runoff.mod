@@ -0,0 +1,127 @@
+# ============================================================
+# Standalone model: Runoff plan for a delisted product
+# Between now and the delist date the product sells down its
+# stock along a markdown ladder (discounts never go back up).
+# Optional top-up orders are allowed; the last period with an
+# order gives the stop-replenishment date. Units left at the
+# delist date go back to the vendor (up to the RTV allowance)
+# or are written off at salvage value.
+#
+# Demand at ladder step m follows a constant elasticity:
+#   rate[t] * (1 - disc[m])^elast
+#
+# Stock is tracked by receipt cohort and charged the shared
+# age-dependent obsolescence curve of aging.mod, the one
+# age_holding_cost.mod uses; the starting stock is already age0
+# periods old.
+#
+#   model extensions/delist_runoff.mod;
+#   data extensions/aging.dat;  data extensions/delist_runoff.dat;
+#   include extensions/delist_runoff.run;
+#
+# For a SKU routed here by slow_movers, read its runoff file over
+# the product data (periods 1..sm_horizon):
+#
+#   update data stock0, rtv_cap, rate;
+#   data slow_runoff_104.dat;
+# ============================================================
+
+set PER ordered;                        # periods up to the delist date
+set MD ordered;                         # markdown ladder, 0% first
+
+param p0 > 0;                           # regular price
+param cost >= 0;                        # unit cost of top-up orders
+param hold >= 0 default 0;              # holding cost per unit-period
+param stock0 >= 0;                      # units on hand now
+param rate{PER} >= 0;                   # full-price demand
+param elast < 0 default -2;             # price elasticity
+param disc{MD} >= 0, < 1;               # markdown depth
+param rtv_value >= 0 default 0;         # vendor credit per returned unit
+param rtv_cap >= 0 default 0;           # units the vendor takes back
+param salvage >= 0 default 0;           # value of written-off units
+
+param age0 integer >= 0 default 0;      # age of stock0 in periods
+set AGE := 0..age0 + card(PER) - 1;
+
+# Obsolescence cost per unit-period, from the shared curve
+model extensions/aging.mod;
+param aged_cost{a in AGE} := age_cost[a];
+
+check: disc[first(MD)] = 0;
+
+# Top-up cohorts (r received, t current)
+set COH := {r in PER, t in PER: ord(r) <= ord(t)};
+
+param md_rate{t in PER, m in MD} := rate[t] * (1 - disc[m])^elast;
+
+# Projected sell-through at full price without any action
+param natural_sell := min(stock0, sum{t in PER} rate[t]);
+
+# -------- Decision Variables --------
+var step{PER,MD} binary;                # ladder step used in t
+var sales{PER,MD} >= 0;
+var topup{PER} >= 0;
+var inv{PER} >= 0;
+var returned >= 0, <= rtv_cap;
+var written_off >= 0;
+var inv0{PER} >= 0;                     # starting stock still on hand
+var sale0{PER} >= 0;                    # sales drawn from it
+var ia{COH} >= 0;                       # top-up cohort r on hand in t
+var sa{COH} >= 0;                       # sales in t drawn from cohort r
+
+var Residual = returned + written_off;
+
+var AgedCost =
+    sum{t in PER} aged_cost[age0 + ord(t) - 1] * inv0[t]
+  + sum{(r,t) in COH} aged_cost[ord(t) - ord(r)] * ia[r,t];
+
+# -------- Objective --------
+maximize RunoffValue:
+    sum{t in PER, m in MD} p0 * (1 - disc[m]) * sales[t,m]
+  - sum{t in PER} (cost * topup[t] + hold * inv[t])
+  - AgedCost
+  + rtv_value * returned + salvage * written_off;
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to OneStep{t in PER}:
+    sum{m in MD} step[t,m] = 1;
+
+# Markdowns are permanent: the ladder position never decreases
+subject to Monotone{t in PER: ord(t) > 1}:
+    sum{m in MD} ord(m) * step[t,m] >= sum{m in MD} ord(m) * step[prev(t),m];
+
+subject to SalesCap{t in PER, m in MD}:
+    sales[t,m] <= md_rate[t,m] * step[t,m];
+
+subject to Balance_First{t in first(PER)}:
+    inv[t] = stock0 + topup[t] - sum{m in MD} sales[t,m];
+
+subject to Balance{t in PER: ord(t) > 1}:
+    inv[t] = inv[prev(t)] + topup[t] - sum{m in MD} sales[t,m];
+
+subject to Disposal{t in last(PER)}:
+    inv[t] = returned + written_off;
+
+# Cohorts: starting stock, then one per top-up
+subject to Start_First{t in first(PER)}:
+    inv0[t] = stock0 - sale0[t];
+
+subject to Start{t in PER: ord(t) > 1}:
+    inv0[t] = inv0[prev(t)] - sale0[t];
+
+subject to CohortNew{t in PER}:
+    ia[t,t] = topup[t] - sa[t,t];
+
+subject to CohortAge{(r,t) in COH: ord(r) < ord(t)}:
+    ia[r,t] = ia[r,prev(t)] - sa[r,t];
+
+subject to CohortSales{t in PER}:
+    sale0[t] + sum{(r,t) in COH} sa[r,t] = sum{m in MD} sales[t,m];
+
+subject to CohortTotal{t in PER}:
+    inv0[t] + sum{(r,t) in COH} ia[r,t] = inv[t];
+
+var md_path{t in PER} = sum{m in MD} disc[m] * step[t,m];
@@ -0,0 +1,91 @@
+# ============================================================
+# Standalone model: Demand anomaly detection and treatment
+# Robust z-scores on each product's sales history:
+#
+#   rz = 0.6745 * (q - median) / MAD
+#
+# Points with |rz| > z_max are anomalies. Treatment is chosen per
+# run: "winsorize" clips them to the robust band, "exclude"
+# drops them from the cleaned history (use_hist = 0), "flag"
+# only reports them. demand_anomalies.run writes the cleaned
+# history to da_clean_file as HIST / sales_hist data (excluded
+# points imputed with the median), which phantom_inventory reads
+# in place of its raw history.
+#
+# demand_anomalies_tune.run picks z_max on a holdout: median and
+# MAD come from the periods before the last n_holdout, the mean
+# of the cleaned fit periods forecasts the holdout, and the z_max
+# with the lowest holdout WAPE is kept.
+#
+#   model extensions/demand_anomalies.mod;
+#   data extensions/demand_anomalies.dat;
+#   include extensions/demand_anomalies.run;
+# ============================================================
+
+set PROD;
+set HIST ordered;                    # past periods, oldest first
+
+param sales_hist{PROD,HIST} >= 0;
+
+param z_max > 0 default 3.5;         # robust z threshold
+param treatment symbolic in {"winsorize", "exclude", "flag"} default "winsorize";
+
+param n_holdout integer >= 0 default 0;   # last periods kept out of the fit
+
+param n_hist := card(HIST);
+set FIT := {h in HIST: ord(h) <= n_hist - n_holdout};
+param n_fit := card(FIT);
+check: n_fit >= 3;
+
+# -------- Robust location and scale --------
+# Lower median: smallest value with at least half the points at or below it
+param med{j in PROD} :=
+    min{h in FIT:
+        card({h2 in FIT: sales_hist[j,h2] <= sales_hist[j,h]}) >= n_fit / 2}
+    sales_hist[j,h];
+
+param absdev{j in PROD, h in HIST} := abs(sales_hist[j,h] - med[j]);
+
+param mad{j in PROD} :=
+    min{h in FIT:
+        card({h2 in FIT: absdev[j,h2] <= absdev[j,h]}) >= n_fit / 2}
+    absdev[j,h];
+
+# A zero MAD (mostly constant series) falls back to 1 unit
+param rz{j in PROD, h in HIST} :=
+    0.6745 * (sales_hist[j,h] - med[j]) / max(mad[j], 1);
+
+param anomaly{j in PROD, h in HIST} binary :=
+    if abs(rz[j,h]) > z_max then 1 else 0;
+
+param n_anomalies{j in PROD} := sum{h in HIST} anomaly[j,h];
+
+# -------- Treatment --------
+param band{j in PROD} := z_max * max(mad[j], 1) / 0.6745;
+
+param sales_clean{j in PROD, h in HIST} :=
+    if anomaly[j,h] = 1 and treatment = "winsorize"
+    then max(0, min(med[j] + band[j], max(med[j] - band[j], sales_hist[j,h])))
+    else sales_hist[j,h];
+
+param use_hist{j in PROD, h in HIST} binary :=
+    if anomaly[j,h] = 1 and treatment = "exclude" then 0 else 1;
+
+param da_clean_file symbolic default "sales_clean.dat";
+
+# -------- Holdout tuning of z_max (demand_anomalies_tune.run) --------
+param zt_hold integer >= 1 default 2;     # holdout periods
+param zt_lo > 0 default 2;                # grid of z_max values
+param zt_step > 0 default 0.5;
+param zt_n integer >= 1 default 7;
+set ZGRID := 1..zt_n;
+param zt_file symbolic default "demand_anomalies_tuned.dat";
+
+param zt_fc{j in PROD} :=
+    sum{h in FIT} use_hist[j,h] * sales_clean[j,h]
+  / max(1, sum{h in FIT} use_hist[j,h]);
+param zt_wape :=
+    sum{j in PROD, h in HIST diff FIT} abs(sales_hist[j,h] - zt_fc[j])
+  / max(1e-6, sum{j in PROD, h in HIST diff FIT} sales_hist[j,h]);
+param zt_err{ZGRID} default Infinity;
+param zt_best integer default 1;
@@ -0,0 +1,69 @@
+# ============================================================
+# Standalone model: Demand driver attribution
+# Explains the change between the previous and the current
+# forecast of each SKU by driver. Both forecasts are products
+# of the same multiplicative drivers:
+#
+#   base     - level, units per period
+#   trend    - (1 + growth)^(t - 1)
+#   season   - seasonal index
+#   price    - (price / p_ref)^elast, elast e.g. from panel_fe
+#   promo    - (1 + promo_lift * promo) times the post-promotion
+#              dip of promo_dip: 1 - sum_l dip_lag[l] * promo[t-l]
+#   event    - 1 + event uplift (e.g. store_events recapture)
+#   weather  - 1 + weather uplift
+#
+# The change fc_cur - fc_prev is split with the log-mean Divisia
+# index, contrib_k = L(fc_cur, fc_prev) * log(f_cur,k / f_prev,k),
+# so the driver contributions add up exactly to the change and
+# offsetting drivers show with opposite signs.
+#
+#   model extensions/demand_attribution.mod;
+#   data extensions/demand_attribution.dat;
+#   include extensions/demand_attribution.run;
+# ============================================================
+
+set PROD;
+set PER ordered;
+set VER := {"prev", "cur"};                   # forecast versions
+set DRIVER ordered := {"base", "trend", "season", "price", "promo", "event", "weather"};
+
+param base{VER,PROD} > 0;
+param growth{VER,PROD} > -1 default 0;        # per period
+param season{VER,PROD,PER} > 0 default 1;
+param price{VER,PROD,PER} > 0;
+param p_ref{PROD} > 0;                        # price of the base level
+param elast{VER,PROD} <= 0 default -2;
+param promo{VER,PROD,PER} binary default 0;
+param promo_lift{PROD} >= 0 default 0;
+param promo_lags integer >= 1 default 2;
+set PLAG := 1..promo_lags;
+param dip_lag{PROD,PLAG} >= 0, < 1 default 0;
+param event{VER,PROD,PER} > -1 default 0;
+param weather{VER,PROD,PER} > -1 default 0;
+
+param fac{v in VER, j in PROD, t in PER, k in DRIVER} :=
+    if k = "base" then base[v,j]
+    else if k = "trend" then (1 + growth[v,j])^(ord(t) - 1)
+    else if k = "season" then season[v,j,t]
+    else if k = "price" then (price[v,j,t] / p_ref[j])^elast[v,j]
+    else if k = "promo" then (1 + promo_lift[j] * promo[v,j,t])
+        * (1 - sum{l in PLAG: ord(t) > l} dip_lag[j,l] * promo[v,j,prev(t,PER,l)])
+    else if k = "event" then 1 + event[v,j,t]
+    else 1 + weather[v,j,t];
+
+check{v in VER, j in PROD, t in PER, k in DRIVER}: fac[v,j,t,k] > 0;
+
+param fc{v in VER, j in PROD, t in PER} := prod{k in DRIVER} fac[v,j,t,k];
+
+# Log-mean weight; equal forecasts need no split
+param lmw{j in PROD, t in PER} :=
+    if abs(fc["cur",j,t] - fc["prev",j,t]) > 1e-9
+    then (fc["cur",j,t] - fc["prev",j,t]) / (log(fc["cur",j,t]) - log(fc["prev",j,t]))
+    else fc["cur",j,t];
+
+param contrib{j in PROD, t in PER, k in DRIVER} :=
+    lmw[j,t] * log(fac["cur",j,t,k] / fac["prev",j,t,k]);
+
+param contrib_sku{j in PROD, k in DRIVER} := sum{t in PER} contrib[j,t,k];
+param fc_sku{v in VER, j in PROD} := sum{t in PER} fc[v,j,t];
@@ -0,0 +1,88 @@
+# ============================================================
+# APO-1 extension: Demand sensing
+# Recent sales versus their forecast give a nowcast ratio for the
+# market; it rescales segment sizes, with a blend weight that
+# decays over the horizon so near periods follow recent sales
+# and later periods fall back to the planned segment sizes:
+#
+#   s_eff[i,t]  = s[i] * (1 + sense_wt[t] * (ratio - 1))
+#   sense_wt[t] = sense_w0 * sense_decay^(ord(t) - 1)
+#
+# Replaces DemandDef, OrderCap and the objective of APO-1.
+#
+#   model APO-1.mod;  model extensions/demand_sensing.mod;
+#   data "Sample 2.dat";  data extensions/demand_sensing.dat;
+#   solve;  display ratio, sense_wt, s_eff;
+#
+# To tune sense_w0 and sense_decay on the holdout first:
+#   include extensions/demand_sensing_tune.run;
+# ============================================================
+
+set RECENT ordered;                        # last few days
+
+param recent_act{RECENT} >= 0;             # actual units sold
+param recent_fc{RECENT} > 0;               # forecast for those days
+
+param sense_w0 >= 0, <= 1 default 0.8;     # blend weight, first period
+param sense_decay >= 0, <= 1 default 0.5;  # per-period decay of the weight
+param ratio_min > 0 default 0.5;           # clamp of the nowcast ratio
+param ratio_max >= ratio_min default 1.5;
+
+param ratio := min(ratio_max, max(ratio_min,
+    sum{r in RECENT} recent_act[r] / sum{r in RECENT} recent_fc[r]));
+
+param sense_wt{t in PER} := sense_w0 * sense_decay^(ord(t) - 1);
+
+param s_eff{i in SEG, t in PER} := s[i] * (1 + sense_wt[t] * (ratio - 1));
+
+param S_eff_max := max{t in PER} sum{i in SEG} s_eff[i,t];
+
+# -------- Holdout backtest for tuning sense_w0 / sense_decay --------
+# At each past origin o the plan forecast bt_fc for the next
+# bt_lead periods is nowcast with that origin's recent ratio
+# bt_ratio and scored against actuals (WAPE) for every point of
+# a grid; demand_sensing_tune.run picks and persists the best.
+set BT_ORIGIN default {};                  # past forecast origins
+param bt_lead integer >= 1 default 3;
+set LEAD := 1..bt_lead;
+param bt_ratio{BT_ORIGIN} > 0;             # recent act / fc at the origin
+param bt_fc{BT_ORIGIN,LEAD} >= 0;          # plan forecast by lead
+param bt_act{BT_ORIGIN,LEAD} >= 0;         # actual sales by lead
+
+param n_grid integer >= 1 default 10;      # grid steps on [0,1]
+set TGRID := 0..n_grid;
+
+param bt_err{a in TGRID, b in TGRID} :=
+    sum{o in BT_ORIGIN, l in LEAD} abs(bt_act[o,l] - bt_fc[o,l]
+        * (1 + (a / n_grid) * (b / n_grid)^(l - 1)
+             * (min(ratio_max, max(ratio_min, bt_ratio[o])) - 1)))
+  / max(1e-6, sum{o in BT_ORIGIN, l in LEAD} bt_act[o,l]);
+
+param tune_file symbolic default "demand_sensing_tuned.dat";
+param tn_err default Infinity;             # best grid error
+param tn_a integer default 0;
+param tn_b integer default 0;
+
+# -------- Objective --------
+maximize Profit_Sensed:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s_eff[i,t] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to DemandDef_Sensed{j in PROD, t in PER}:
+    d[j,t] = sum{i in SEG} s_eff[i,t] * x[i,j,t];
+
+subject to OrderCap_Sensed{j in PROD, t in PER}:
+    u[j,t] <= y[j,t] * ((card(PER) - ord(t) + 1) * S_eff_max);
+
+drop DemandDef;
+drop OrderCap;
+objective Profit_Sensed;
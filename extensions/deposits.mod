@@ -0,0 +1,60 @@
+# ============================================================
+# APO-1 extension: Container deposits
+# Deposit items (bottles, crates) carry a pass-through deposit:
+# the shopper pays p + dep[j] and gets dep[j] back on return;
+# the retailer pays dep_in[j] to the supplier per unit bought
+# and recovers it when the empties go back. p stays the
+# retailer's own price, so revenue and margin in Profit exclude
+# deposits, while the shopper's choice sees the full outlay:
+#
+#   surplus = alpha[i,j,t] - dep[j] - p[j,t]
+#
+# Deposit flows are reported apart from margin. Unreturned
+# deposits (shoppers keeping containers) are shown as
+# breakage; whether to book them is an accounting choice.
+#
+#   model APO-1.mod;  model extensions/deposits.mod;
+#   data "Sample 2.dat";  data extensions/deposits.dat;
+#   solve;  display Deposit_In, Deposit_Out, Deposit_Net;
+# ============================================================
+
+param dep{PROD} >= 0 default 0;                 # charged to shoppers
+param dep_in{PROD} >= 0 default 0;              # charged by supplier
+param dep_ret{PROD} >= 0, <= 1 default 1;       # containers returned
+
+# Reservation price left for the item itself
+param alpha_net{i in SEG, j in PROD, t in PER} := max(0, alpha[i,j,t] - dep[j]);
+
+check{j in PROD, t in PER}: p_ub[j,t] >= dep[j];
+
+# -------- Deposit flows (not part of margin) --------
+var Deposit_In{j in PROD} =                     # collected from shoppers
+    sum{t in PER} dep[j] * d[j,t];
+
+var Deposit_Out{j in PROD} =                    # refunded to shoppers
+    sum{t in PER} dep[j] * dep_ret[j] * d[j,t];
+
+var Deposit_Supplier{j in PROD} =               # paid, net of empties sent back
+    sum{t in PER} dep_in[j] * (u[j,t] - dep_ret[j] * d[j,t]);
+
+var Deposit_Net{j in PROD} =
+    Deposit_In[j] - Deposit_Out[j] - Deposit_Supplier[j];
+
+var Breakage{j in PROD} =
+    sum{t in PER} dep[j] * (1 - dep_ret[j]) * d[j,t];
+
+# ============================================================
+# Constraints (replace the price-bound and choice rows)
+# ============================================================
+
+subject to PriceUpper_Dep{j in PROD, t in PER}:
+    p[j,t] <= (p_ub[j,t] - dep[j]) * z[j];
+
+subject to NonNegUtility_Dep{i in SEG, t in PER}:
+    sum{k in PROD} alpha_net[i,k,t] * x[i,k,t] - sum{k in PROD} g[i,k,t] >= 0;
+
+subject to UtilityChoice_Dep{i in SEG, t in PER, j in PROD}:
+    sum{k in PROD} alpha_net[i,k,t] * x[i,k,t] - sum{k in PROD} g[i,k,t]
+    >= alpha_net[i,j,t] * z[j] - w[j,t];
+
+drop PriceUpper;  drop NonNegUtility;  drop UtilityChoice;
@@ -0,0 +1,101 @@
+# ============================================================
+# APO-1 extension: Display slots and retail media placement
+# Promoted placements (endcaps, banners) lift demand much like a
+# price cut. Placing product j in slot type m in period t raises
+# every segment's reservation price for it by lift[i,j,m]:
+#
+#   surplus = alpha[i,j,t] + lift[i,j,m] * disp[j,t,m] - p[j,t]
+#
+# Slots are limited per period (slot_cap). A placement costs
+# slot_cost and may bring vendor funding (fund). Placement and
+# price are chosen together.
+#
+# The lift in the chosen surplus is linearized with
+#   e[i,j,t,m] = disp[j,t,m] * x[i,j,t]
+#
+#   model APO-1.mod;  model extensions/display_slots.mod;
+#   data "Sample 2.dat";  data extensions/display_slots.dat;
+#   solve;  display disp;
+# ============================================================
+
+set DISPLAY;                                      # slot types
+
+param lift{SEG,PROD,DISPLAY} >= 0 default 0;      # reservation price lift
+param slot_cap{DISPLAY,PER} integer >= 0;         # slots available
+param slot_cost{DISPLAY} >= 0 default 0;          # per placement
+param fund{PROD,DISPLAY} >= 0 default 0;          # vendor funding
+
+# Price bound with the largest lift
+param p_ub_disp{j in PROD, t in PER} :=
+    p_ub[j,t] + max{i in SEG, m in DISPLAY} lift[i,j,m];
+
+# -------- Decision variables --------
+var disp{PROD,PER,DISPLAY} binary;
+var e{SEG,PROD,PER,DISPLAY} >= 0, <= 1;           # disp * x
+
+# ============================================================
+# Objective: APO-1 profit plus placement economics
+# ============================================================
+maximize Profit_Display:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+      + sum{m in DISPLAY} (fund[j,m] - slot_cost[m]) * disp[j,t,m]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) At most one placement per listed product and period
+subject to OneDisplay{j in PROD, t in PER}:
+    sum{m in DISPLAY} disp[j,t,m] <= z[j];
+
+# 2) Slot capacity
+subject to SlotCap{m in DISPLAY, t in PER}:
+    sum{j in PROD} disp[j,t,m] <= slot_cap[m,t];
+
+# 3) e = disp * x
+subject to e_up1{i in SEG, j in PROD, t in PER, m in DISPLAY}:
+    e[i,j,t,m] <= disp[j,t,m];
+
+subject to e_up2{i in SEG, j in PROD, t in PER, m in DISPLAY}:
+    e[i,j,t,m] <= x[i,j,t];
+
+subject to e_low{i in SEG, j in PROD, t in PER, m in DISPLAY}:
+    e[i,j,t,m] >= disp[j,t,m] + x[i,j,t] - 1;
+
+# 4) Prices may rise into the lift
+subject to PriceUpper_Disp{j in PROD, t in PER}:
+    p[j,t] <= p_ub_disp[j,t] * z[j];
+
+subject to g_up1_Disp{i in SEG, j in PROD, t in PER}:
+    g[i,j,t] <= p_ub_disp[j,t] * x[i,j,t];
+
+subject to g_low_Disp{i in SEG, j in PROD, t in PER}:
+    g[i,j,t] >= p[j,t] - p_ub_disp[j,t] * (1 - x[i,j,t]);
+
+subject to w_up1_Disp{j in PROD, t in PER}:
+    w[j,t] <= p_ub_disp[j,t] * z[j];
+
+subject to w_low_Disp{j in PROD, t in PER}:
+    w[j,t] >= p[j,t] - p_ub_disp[j,t] * (1 - z[j]);
+
+# 5) Max-surplus choice with display lift
+subject to NonNegUtility_Disp{i in SEG, t in PER}:
+    sum{k in PROD} (alpha[i,k,t] * x[i,k,t]
+                    + sum{m in DISPLAY} lift[i,k,m] * e[i,k,t,m])
+  - sum{k in PROD} g[i,k,t] >= 0;
+
+subject to UtilityChoice_Disp{i in SEG, t in PER, j in PROD}:
+    sum{k in PROD} (alpha[i,k,t] * x[i,k,t]
+                    + sum{m in DISPLAY} lift[i,k,m] * e[i,k,t,m])
+  - sum{k in PROD} g[i,k,t]
+    >= alpha[i,j,t] * z[j] + sum{m in DISPLAY} lift[i,j,m] * disp[j,t,m] - w[j,t];
+
+drop PriceUpper;  drop g_up1;  drop g_low;  drop w_up1;  drop w_low;
+drop NonNegUtility;  drop UtilityChoice;
+objective Profit_Display;
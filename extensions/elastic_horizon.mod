@@ -0,0 +1,48 @@
+# ============================================================
+# APO-1 extension: Mixed-granularity horizon
+# Periods may differ in length, e.g. days for the first two
+# weeks and weeks thereafter. Segment sizes and holding costs in
+# the data are per base period (a week); each period scales them
+# by its length per_len[t] (1/7 for a day). Results roll up to
+# reporting weeks through week_of.
+# Replaces DemandDef, OrderCap and the objective of APO-1.
+#
+#   model APO-1.mod;  model extensions/elastic_horizon.mod;
+#   data "Sample 2.dat";  data extensions/elastic_horizon.dat;
+#   solve;  display WeekDemand;
+# ============================================================
+
+param per_len{PER} > 0 default 1;              # length in base periods
+param week_of{PER} symbolic;                   # reporting week
+
+set WEEK := setof{t in PER} week_of[t];
+
+# -------- Objective --------
+maximize Profit_Elastic:
+    sum{t in PER, j in PROD} (
+        per_len[t] * sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - per_len[t] * h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to DemandDef_Elastic{j in PROD, t in PER}:
+    d[j,t] = per_len[t] * sum{i in SEG} s[i] * x[i,j,t];
+
+subject to OrderCap_Elastic{j in PROD, t in PER}:
+    u[j,t] <= y[j,t] * sum{t2 in PER: ord(t2) >= ord(t)} per_len[t2] * S_total;
+
+# -------- Weekly roll-up --------
+var WeekDemand{j in PROD, wk in WEEK} =
+    sum{t in PER: week_of[t] = wk} d[j,t];
+
+var WeekOrders{j in PROD, wk in WEEK} =
+    sum{t in PER: week_of[t] = wk} u[j,t];
+
+drop DemandDef;  drop OrderCap;
+objective Profit_Elastic;
@@ -0,0 +1,89 @@
+# ============================================================
+# APO-1 extension: Retail fiscal calendar
+# Maps planning periods (weeks) onto a 4-4-5, 4-5-4 or 5-4-4
+# fiscal year, including 53-week years (the extra week goes to
+# the last month), or onto ISO 8601 weeks of calendar year
+# cal_year, where a week belongs to the month holding its
+# Thursday. The plan rolls up to fiscal months and quarters.
+# Like-for-like comparisons use last year's units by fiscal
+# week; after a 53-week year last year is restated one week on,
+# so week w compares with last year's week w + 1.
+#
+# fiscal_buckets.run writes the period-to-month mapping to
+# cal_file as MONTH / month_of / weeks data, so that monthly
+# budgets (open_to_buy) are bucketed by the same calendar.
+#
+#   model APO-1.mod;  model extensions/fiscal_calendar.mod;
+#   data "Sample 2.dat";  data extensions/fiscal_calendar.dat;
+#   solve;  include extensions/fiscal_calendar.run;
+# ============================================================
+
+param cal_pattern symbolic in {"445", "454", "544", "iso"} default "454";
+param cal_year integer >= 1583;               # calendar year, ISO mode
+param first_week integer >= 1 default 1;      # fiscal week of the first period
+param cal_file symbolic default "fiscal_buckets.dat";
+
+set MON := 1..12;
+set QTR := 1..4;
+
+# -------- ISO 8601 weeks of cal_year --------
+param leap binary :=
+    if (cal_year mod 4 = 0 and cal_year mod 100 <> 0) or cal_year mod 400 = 0
+    then 1 else 0;
+
+# Weekday of 1 January (1 = Monday ... 7 = Sunday), Gauss's rule
+param jan1_dow{y in {cal_year - 1, cal_year}} :=
+    1 + (5 * ((y - 1) mod 4) + 4 * ((y - 1) mod 100) + 6 * ((y - 1) mod 400)) mod 7;
+
+param iso_weeks{y in {cal_year - 1, cal_year}} :=
+    if jan1_dow[y] = 4
+       or (jan1_dow[y] = 3 and ((y mod 4 = 0 and y mod 100 <> 0) or y mod 400 = 0))
+    then 53 else 52;
+
+# Day of the year (1 January = 0) of the Thursday in ISO week w
+param mon1 := if jan1_dow[cal_year] <= 4 then 1 - jan1_dow[cal_year] else 8 - jan1_dow[cal_year];
+param iso_thu{w in 1..53} := mon1 + 7 * (w - 1) + 3;
+
+param mdays{m in MON} :=
+    if m = 2 then 28 + leap else if m in {4, 6, 9, 11} then 30 else 31;
+param day_end{m in MON} := sum{m2 in MON: m2 <= m} mdays[m2];
+
+# -------- Weeks and months of the year --------
+param year_weeks integer in {52, 53} default
+    (if cal_pattern = "iso" then iso_weeks[cal_year] else 52);
+param prior_53 binary default                 # last year had 53 weeks
+    (if cal_pattern <> "iso" then 0
+     else if iso_weeks[cal_year - 1] = 53 then 1 else 0);
+
+check{k in 1..1: cal_pattern = "iso"}: year_weeks = iso_weeks[cal_year];
+
+param fweek{t in PER} := first_week + ord(t) - 1;
+check{t in PER}: fweek[t] <= year_weeks;
+
+# Weeks per month: from the pattern, repeated each quarter, or
+# the ISO weeks whose Thursday falls in the month
+param mlen{m in MON} :=
+    if cal_pattern = "iso"
+    then card({w in 1..year_weeks: iso_thu[w] < day_end[m]
+                                   and (m = 1 or iso_thu[w] >= day_end[m-1])})
+    else num(substr(cal_pattern, (m - 1) mod 3 + 1, 1))
+       + (if m = 12 and year_weeks = 53 then 1 else 0);
+
+param month_end{m in MON} := sum{m2 in MON: m2 <= m} mlen[m2];
+
+param fmonth{t in PER} := min{m in MON: fweek[t] <= month_end[m]} m;
+param fquarter{t in PER} := ceil(fmonth[t] / 3);
+param fm_name{m in MON} symbolic := sprintf("FM%02d", m);
+
+# -------- Like-for-like --------
+param ly_units{1..53} >= 0 default 0;         # last year, by fiscal week
+param ly_week{t in PER} := fweek[t] + prior_53;
+param ly_comp{t in PER} := if ly_week[t] <= 53 then ly_units[ly_week[t]] else 0;
+
+# -------- Rollups of the plan --------
+var MonthUnits{m in MON} = sum{t in PER, j in PROD: fmonth[t] = m} d[j,t];
+var MonthRevenue{m in MON} =
+    sum{t in PER, j in PROD, i in SEG: fmonth[t] = m} s[i] * g[i,j,t];
+var QtrUnits{q in QTR} = sum{t in PER, j in PROD: fquarter[t] = q} d[j,t];
+var QtrRevenue{q in QTR} =
+    sum{t in PER, j in PROD, i in SEG: fquarter[t] = q} s[i] * g[i,j,t];
@@ -0,0 +1,87 @@
+# ============================================================
+# Standalone model: Stock, cross-dock or direct-to-store per SKU-DC
+# For each SKU and the stores a DC serves, picks one flow path:
+#
+#   stock  - vendor -> DC stock -> stores
+#   xdock  - vendor -> DC dock -> stores, no DC stock
+#   direct - vendor -> stores
+#
+# Weekly cost of a path for SKU j at DC dc, with V = weekly
+# units over the DC's stores and sd the weekly demand std. dev.:
+#
+#   handling + transport     (hc[dc,path] + tc[j,path]) * V
+#   store safety stock       z * sum_st sd[j,st] * sqrt(lead[j,path]) * hs[j]
+#   store cycle stock        drop[j,path] / 2 per store * hs[j]
+#   DC stock (stock only)    (dc_lot[j] / 2 + z * sqrt(sum_st sd^2)
+#                             * sqrt(lead_dc[j])) * hd[j]
+#
+# Stocking uses DC slots, cross-docking uses dock throughput,
+# and direct needs a vendor that drops to stores.
+#
+#   model extensions/flow_path.mod;
+#   data extensions/flow_path.dat;
+#   solve;  include extensions/flow_path.run;
+# ============================================================
+
+set PROD;
+set DC;
+set STORE;
+set PATH := {"stock", "xdock", "direct"};
+
+param serves{STORE} symbolic in DC;           # DC serving each store
+
+param vel{PROD,STORE} >= 0;                   # weekly units
+param sd{PROD,STORE} >= 0;                    # weekly std. dev.
+
+param hc{DC,PATH} >= 0;                       # DC handling per unit
+param tc{PROD,PATH} >= 0;                     # transport per unit
+param lead{PROD,PATH} >= 0;                   # weeks, order to store shelf
+param lead_dc{PROD} >= 0 default 1;           # vendor -> DC, stock path
+param drop{PROD,PATH} >= 0;                   # units per store delivery
+param dc_lot{PROD} >= 0 default 0;            # vendor lot into DC stock
+param hs{PROD} >= 0;                          # weekly holding, store
+param hd{PROD} >= 0;                          # weekly holding, DC
+param z_ss >= 0 default 1.65;
+
+param direct_ok{PROD} binary default 0;       # vendor ships to stores
+param slots{DC} >= 0 default Infinity;        # SKUs the DC can stock
+param xd_cap{DC} >= 0 default Infinity;       # weekly cross-dock units
+
+set SD{dc in DC} := {st in STORE: serves[st] = dc};
+set LANE := {j in PROD, dc in DC: sum{st in SD[dc]} vel[j,st] > 0};
+
+param V{(j,dc) in LANE} := sum{st in SD[dc]} vel[j,st];
+
+param cost{(j,dc) in LANE, a in PATH} :=
+    (hc[dc,a] + tc[j,a]) * V[j,dc]
+  + z_ss * sum{st in SD[dc]} sd[j,st] * sqrt(lead[j,a]) * hs[j]
+  + card(SD[dc]) * drop[j,a] / 2 * hs[j]
+  + (if a = "stock" then
+        (dc_lot[j] / 2 + z_ss * sqrt(sum{st in SD[dc]} sd[j,st]^2) * sqrt(lead_dc[j]))
+        * hd[j]
+     else 0);
+
+# -------- Decision variables --------
+var use{LANE,PATH} binary;
+
+# ============================================================
+# Objective: weekly flow cost
+# ============================================================
+minimize Flow_Cost:
+    sum{(j,dc) in LANE, a in PATH} cost[j,dc,a] * use[j,dc,a];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to OnePath{(j,dc) in LANE}:
+    sum{a in PATH} use[j,dc,a] = 1;
+
+subject to DirectEligible{(j,dc) in LANE: direct_ok[j] = 0}:
+    use[j,dc,"direct"] = 0;
+
+subject to DCSlots{dc in DC: slots[dc] < Infinity}:
+    sum{(j,dc) in LANE} use[j,dc,"stock"] <= slots[dc];
+
+subject to XDockCap{dc in DC: xd_cap[dc] < Infinity}:
+    sum{(j,dc) in LANE} V[j,dc] * use[j,dc,"xdock"] <= xd_cap[dc];
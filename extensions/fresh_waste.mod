@@ -0,0 +1,91 @@
+# ============================================================
+# APO-1 extension: Fresh categories, waste objective
+# Stock is tracked by receipt cohort and expires life[j]
+# periods after receipt; whatever is left at the end of the
+# horizon is waste as well. Each category picks its goal in
+# objective_mode:
+#   "profit" - APO-1 profit net of the cost of wasted units
+#   "waste"  - wasted units, weighted by waste_wt, are minimized
+#              subject to the category's availability target
+#              (share of shoppers served each period)
+# Both kinds of category share one objective, and an optional
+# profit floor applies to the whole run. Categories come from
+# categories.mod. Replaces APO-1's inventory balance and end-
+# inventory rule.
+#
+#   model APO-1.mod;  model extensions/categories.mod;
+#   model extensions/fresh_waste.mod;
+#   data "Sample 2.dat";  data extensions/categories.dat;
+#   data extensions/fresh_waste.dat;
+#   include extensions/fresh_waste.run;
+# ============================================================
+
+param objective_mode{CAT} symbolic in {"profit", "waste"} default "profit";
+param waste_wt{CAT} >= 0 default 1;            # per wasted unit, "waste" mode
+
+param life{PROD} integer >= 1;                 # shelf life in periods
+param waste_cost{j in PROD, t in PER} >= 0 default c[j,t];  # per wasted unit
+param avail_target{CAT,PER} >= 0, <= 1 default 0;  # share of shoppers served
+param profit_min default -Infinity;            # floor on total profit
+
+# Fresh cohorts: received in r, still sellable in t
+set FRESH := {j in PROD, r in PER, t in PER: ord(r) <= ord(t) and ord(t) - ord(r) < life[j]};
+
+var Fk{FRESH} >= 0;                            # cohort stock after sales
+var fs{FRESH} >= 0;                            # sales from the cohort
+
+# A cohort is wasted at the end of its last sellable period,
+# or at the end of the horizon, whichever comes first
+var Waste{j in PROD} =
+    sum{(j,r,t) in FRESH: ord(t) - ord(r) = life[j] - 1 or t = last(PER)} Fk[j,r,t];
+
+var WasteCost{j in PROD} =
+    sum{(j,r,t) in FRESH: ord(t) - ord(r) = life[j] - 1 or t = last(PER)}
+        waste_cost[j,t] * Fk[j,r,t];
+
+var Contrib_Fresh{j in PROD} =
+    sum{t in PER} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - f[j] * z[j]
+  - WasteCost[j];
+
+var Profit_Fresh_Value = sum{j in PROD} Contrib_Fresh[j];
+
+var Cat_Waste{k in CAT} = sum{j in PROD: cat_of[j] = k} Waste[j];
+
+# -------- Objective --------
+maximize Fresh_Objective:
+    sum{j in PROD: objective_mode[cat_of[j]] = "profit"} Contrib_Fresh[j]
+  - sum{k in CAT: objective_mode[k] = "waste"} waste_wt[k] * Cat_Waste[k];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Cohort balance
+subject to FreshNew{j in PROD, t in PER}:
+    Fk[j,t,t] = u[j,t] - fs[j,t,t];
+
+subject to FreshAge{(j,r,t) in FRESH: ord(r) < ord(t)}:
+    Fk[j,r,t] = Fk[j,r,prev(t)] - fs[j,r,t];
+
+subject to FreshSales{j in PROD, t in PER}:
+    sum{(j,r,t) in FRESH} fs[j,r,t] = d[j,t];
+
+# 2) Carried stock = cohorts that are still sellable next period
+subject to FreshCarry{j in PROD, t in PER}:
+    I[j,t] = sum{(j,r,t) in FRESH: ord(t) - ord(r) < life[j] - 1 and t <> last(PER)} Fk[j,r,t];
+
+# 3) Availability target per category and profit floor
+subject to Availability{k in CAT, t in PER: avail_target[k,t] > 0}:
+    sum{j in PROD: cat_of[j] = k} d[j,t] >= avail_target[k,t] * S_total;
+
+subject to ProfitFloor{if profit_min > -Infinity}:
+    Profit_Fresh_Value >= profit_min;
+
+drop InvBal_First;  drop InvBal;  drop EndInvZero;
+objective Fresh_Objective;
@@ -0,0 +1,48 @@
+# ============================================================
+# APO-1 extension: Known-value items (KVI) and price image
+# KVIs must be carried and their weighted price index versus
+# competitors must stay at or below a target each period.
+# The overall basket index (all products with a competitor
+# price) is reported for every solved scenario.
+#
+#   model APO-1.mod;  model extensions/kvi_price_index.mod;
+#   data "Sample 2.dat";  data extensions/kvi_price_index.dat;
+#   solve;  display KVI_Index, Basket_Index;
+# ============================================================
+
+set KVI within PROD;                   # known-value items
+
+param comp{PROD,PER} >= 0 default 0;   # competitor shelf price (0 = none)
+param kvi_wt{KVI} > 0 default 1;       # weight in the KVI index
+param basket_wt{PROD} >= 0 default 1;  # weight in the basket index
+
+param idx_target{PER} > 0 default 1;   # max KVI index (1 = parity)
+param kvi_cap{KVI} > 0 default Infinity; # max item-level index
+
+check{j in KVI, t in PER}: comp[j,t] > 0;
+
+# -------- Reported indices --------
+var KVI_Index{t in PER} =
+    sum{j in KVI} kvi_wt[j] * p[j,t] / sum{j in KVI} kvi_wt[j] * comp[j,t];
+
+var Basket_Index{t in PER} =
+    sum{j in PROD: comp[j,t] > 0} basket_wt[j] * p[j,t]
+  / sum{j in PROD: comp[j,t] > 0} basket_wt[j] * comp[j,t];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) KVIs are always on the shelf (an unlisted KVI would price at 0
+#    and flatter the index)
+subject to KVIListed{j in KVI}:
+    z[j] = 1;
+
+# 2) Weighted KVI price index at or below target
+subject to KVIIndexCap{t in PER}:
+    sum{j in KVI} kvi_wt[j] * p[j,t]
+    <= idx_target[t] * sum{j in KVI} kvi_wt[j] * comp[j,t];
+
+# 3) Item-level cap versus the competitor price
+subject to KVIItemCap{j in KVI, t in PER: kvi_cap[j] < Infinity}:
+    p[j,t] <= kvi_cap[j] * comp[j,t];
@@ -0,0 +1,27 @@
+# ============================================================
+# APO-1 extension: Price-change execution capacity
+# Stores can only re-tag a limited number of items per period
+# (e.g. per day). A price change between consecutive periods
+# needs a label change, and label changes per period are capped,
+# so the optimizer spreads price moves over the periods.
+#
+#   model APO-1.mod;  model extensions/label_capacity.mod;
+#   data "Sample 2.dat";  data extensions/label_capacity.dat;
+#   solve;  display relabel;
+# ============================================================
+
+param label_cap{PER} >= 0 default Infinity;     # label changes per period
+param label_min_move >= 0 default 0.005;        # smaller moves need no label
+
+var relabel{j in PROD, t in PER: ord(t) > 1} binary;
+
+# A price move beyond the tolerance requires a label change
+subject to RelabelUp{j in PROD, t in PER: ord(t) > 1}:
+    p[j,t] - p[j,prev(t)] <= label_min_move + p_ub[j,t] * relabel[j,t];
+
+subject to RelabelDown{j in PROD, t in PER: ord(t) > 1}:
+    p[j,prev(t)] - p[j,t] <= label_min_move + p_ub[j,prev(t)] * relabel[j,t];
+
+# Execution capacity per period
+subject to LabelCap{t in PER: ord(t) > 1 and label_cap[t] < Infinity}:
+    sum{j in PROD} relabel[j,t] <= label_cap[t];
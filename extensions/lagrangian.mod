@@ -0,0 +1,70 @@
+# ============================================================
+# APO-1 extension: Lagrangian decomposition
+# Relaxes the two constraints that couple the commercial side
+# (assortment, prices, choices) with the operational side
+# (setups, orders, inventory):
+#   DemandDef              with multipliers lam[j,t] (free)
+#   SetupRequiresOffering  with multipliers mu[j,t] >= 0
+# The relaxed problem splits into ChoiceSub and InvSub; their
+# summed optimum is an upper bound on APO-1's profit. A primal
+# heuristic (fix z, re-solve APO-1) gives the lower bound.
+#
+# Driven by extensions/lagrangian.run.
+# ============================================================
+
+# -------- Multipliers --------
+param lam{PROD,PER} default 0;
+param mu{PROD,PER} >= 0 default 0;
+
+# Relaxing DemandDef leaves d free in the inventory subproblem,
+# so bound it by the market size (redundant in APO-1 itself).
+subject to DemandBound{j in PROD, t in PER}:
+    d[j,t] <= S_total;
+
+# -------- Subproblem objectives --------
+maximize LagChoice:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        + lam[j,t] * sum{i in SEG} s[i] * x[i,j,t]
+        + mu[j,t] * z[j]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+maximize LagInv:
+    sum{t in PER, j in PROD} (
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+        - lam[j,t] * d[j,t]
+        - mu[j,t] * y[j,t]
+    );
+
+# -------- Subproblems --------
+problem ChoiceSub: z, p, x, g, w, LagChoice,
+    SingleChoice, ChoiceRequiresOffering, PriceUpper,
+    g_up1, g_up2, g_low, w_up1, w_up2, w_low,
+    NonNegUtility, UtilityChoice;
+
+problem InvSub: y, u, I, d, LagInv,
+    InvBal_First, InvBal, EndInvZero, OrderCap, DemandBound;
+
+problem Initial;
+objective Profit;
+
+# -------- Coordinator state --------
+param iter_max integer > 0 default 50;
+param gap_tol >= 0 default 1e-3;     # stop at this relative gap
+param theta > 0 default 2;           # Polyak step factor
+param stall_max integer > 0 default 5;
+
+param UB default Infinity;           # best dual (Lagrangian) bound
+param LB default -Infinity;          # best feasible profit
+param L_val;                         # current Lagrangian value
+param stall integer default 0;
+param step;
+param gnorm;
+param gap;
+
+param sg_lam{PROD,PER};              # subgradient for lam
+param sg_mu{PROD,PER};               # subgradient for mu
+param z_best{PROD} binary default 0;
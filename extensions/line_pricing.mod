@@ -0,0 +1,53 @@
+# ============================================================
+# APO-1 extension: Line-price logic across sizes
+# Products in the same line are sized versions of one item
+# (vol[j] = units of content). When both are listed:
+#
+#   - a larger size has a unit price at least size_gap below
+#     every smaller size of its line
+#   - a family pack's unit price sits within a discount band
+#     [pack_lo, pack_hi] of its single
+#
+# Unit prices are p[j,t] / vol[j], so the rules stay linear; a
+# big-M on z switches them off when either item is unlisted.
+#
+#   model APO-1.mod;  model extensions/line_pricing.mod;
+#   data "Sample 2.dat";  data extensions/line_pricing.dat;
+#   solve;  display Unit_Price;
+# ============================================================
+
+set LINE;
+param line_of{PROD} symbolic in LINE union {"none"} default "none";
+param vol{PROD} > 0 default 1;                    # content per item
+
+param size_gap >= 0, < 1 default 0.05;            # min unit-price step
+
+set FAMILY within {PROD,PROD};                    # (pack, single) pairs
+param pack_lo{FAMILY} >= 0, < 1 default 0.05;     # min unit discount
+param pack_hi{(k,j) in FAMILY} >= pack_lo[k,j], < 1 default 0.25;
+
+# Ordered size pairs within a line: (small, large)
+set SIZE_PAIR := {j in PROD, k in PROD:
+    line_of[j] <> "none" and line_of[j] = line_of[k] and vol[j] < vol[k]};
+
+check{(k,j) in FAMILY}: vol[k] > vol[j];
+
+var Unit_Price{j in PROD, t in PER} = p[j,t] / vol[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Larger sizes carry a lower unit price
+subject to SizeLadder{(j,k) in SIZE_PAIR, t in PER}:
+    p[k,t] / vol[k] <= (1 - size_gap) * p[j,t] / vol[j]
+                       + p_ub[k,t] / vol[k] * (2 - z[j] - z[k]);
+
+# 2) Family pack within its band below the single
+subject to PackBandLow{(k,j) in FAMILY, t in PER}:
+    p[k,t] / vol[k] >= (1 - pack_hi[k,j]) * p[j,t] / vol[j]
+                       - p_ub[j,t] / vol[j] * (2 - z[j] - z[k]);
+
+subject to PackBandHigh{(k,j) in FAMILY, t in PER}:
+    p[k,t] / vol[k] <= (1 - pack_lo[k,j]) * p[j,t] / vol[j]
+                       + p_ub[k,t] / vol[k] * (2 - z[j] - z[k]);
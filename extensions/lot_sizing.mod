@@ -0,0 +1,31 @@
+# ============================================================
+# APO-1 extension: Capacitated lot-sizing with vendor schedules
+# Each product is sourced from one vendor. Vendors ship only on
+# their shipping periods and cap the pallets shipped per period;
+# products may also carry their own per-order cap in order
+# units. With these, APO-1's ordering becomes a capacitated
+# Wagner-Whitin lot-sizing MIP.
+#
+# Vendors come from vendors.mod and pack sizes from packs.mod:
+#   model APO-1.mod;  model extensions/vendors.mod;
+#   model extensions/packs.mod;  model extensions/lot_sizing.mod;
+#   data "Sample 2.dat";  data extensions/vendors.dat;
+#   data extensions/packs.dat;  data extensions/lot_sizing.dat;
+#   solve;  display u;
+# ============================================================
+
+param ships{VEND,PER} binary default 1;     # 1 = vendor ships in t
+param vcap{VEND,PER} >= 0 default Infinity; # pallets per shipment
+param ucap{PROD,PER} >= 0 default Infinity; # lot cap in order units
+
+# 1) Orders only on the vendor's shipping periods
+subject to ShipCalendar{j in PROD, t in PER: ships[vendor[j],t] = 0}:
+    y[j,t] = 0;
+
+# 2) Vendor capacity shared by all of its products
+subject to VendorCap{v in VEND, t in PER: vcap[v,t] < Infinity}:
+    sum{j in PROD: vendor[j] = v} to_uom[j,"pallet"] * u[j,t] <= vcap[v,t];
+
+# 3) Product lot cap, active only when an order is placed
+subject to LotCap{j in PROD, t in PER: ucap[j,t] < Infinity}:
+    u[j,t] <= order_each[j] * ucap[j,t] * y[j,t];
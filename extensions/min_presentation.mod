@@ -0,0 +1,20 @@
+# ============================================================
+# APO-1 extension: Minimum presentation stock
+# A listed product must keep at least its planogram display
+# quantity on the shelf at the end of every period, so orders
+# cover demand plus the display fill. The last period is exempt
+# because APO-1 sells the horizon down to zero (EndInvZero).
+# allocation.mod takes the same min_pres as its default store
+# display quantity (min_disp).
+#
+#   model APO-1.mod;  model extensions/min_presentation.mod;
+#   data "Sample 2.dat";  data extensions/min_presentation.dat;
+#   solve;
+# ============================================================
+
+param min_pres{PROD} >= 0 default 0;            # planogram display units
+param min_pres_t{j in PROD, t in PER} >= 0 default min_pres[j];
+
+# Listed products never drop below their display quantity
+subject to MinPresentation{j in PROD, t in PER: t <> last(PER) and min_pres_t[j,t] > 0}:
+    I[j,t] >= min_pres_t[j,t] * z[j];
@@ -0,0 +1,179 @@
+# ============================================================
+# APO-1 extension: Multibuy deals ("2 for $5") and bundles
+# Besides its unit price, a product may run one deal per period
+# from DEAL: buy qty[m] units at deal unit price pd[j,t]. A deal
+# is an extra option in each segment's choice. Shoppers value
+# the k-th unit at alpha * decay[i]^(k-1), so buying qty[m]
+# units is worth
+#
+#   A[i,j,t,m] = alpha[i,j,t] * (1 + decay + ... + decay^(qty-1))
+#
+# and the deal is chosen when A - qty * pd beats every other
+# offered option. The optimizer picks whether to run a deal,
+# which threshold, and its price, next to the unit price.
+#
+# A bundle b is a fixed basket of different products, bqty[b,j]
+# units of each component j, sold at one bundle price pb[b,t].
+# It is valued the same way, component by component,
+#
+#   AB[i,b,t] = sum over j of alpha[i,j,t] * (1 + ... + decay^(bqty-1))
+#
+# can run only while all its components are listed, and must be
+# cheaper than buying the components at their unit prices.
+#
+# Deal and bundle prices are linearized like APO-1's:
+#   gd[i,j,t,m] = pd[j,t] * xd[i,j,t,m]
+#   wd[j,t,m]   = pd[j,t] * mb[j,t,m]
+#   gb[i,b,t]   = pb[b,t] * xb[i,b,t]
+#   wb[b,t]     = pb[b,t] * bon[b,t]
+#
+#   model APO-1.mod;  model extensions/multibuy.mod;
+#   data "Sample 2.dat";  data extensions/multibuy.dat;
+#   solve;  display mb, pd, bon, pb;
+# ============================================================
+
+set DEAL;                                     # deal mechanics
+param qty{DEAL} integer >= 2;                 # units to qualify
+param decay{SEG} >= 0, <= 1 default 0.6;      # value of each further unit
+param deal_ok{PROD} binary default 1;         # product may run deals
+
+param A{i in SEG, j in PROD, t in PER, m in DEAL} :=
+    alpha[i,j,t] * sum{k in 0..qty[m]-1} decay[i]^k;
+
+set BUNDLE default {};                        # fixed multi-product baskets
+param bqty{BUNDLE,PROD} integer >= 0 default 0;   # component units
+param AB{i in SEG, b in BUNDLE, t in PER} :=
+    sum{j in PROD: bqty[b,j] > 0} alpha[i,j,t] * sum{k in 0..bqty[b,j]-1} decay[i]^k;
+param pb_ub{b in BUNDLE, t in PER} := sum{j in PROD} bqty[b,j] * p_ub[j,t];
+
+# Most units of j one shopper can take in a period
+param basket_max{j in PROD} :=
+    max(1, max{m in DEAL} qty[m], max{b in BUNDLE} bqty[b,j]);
+
+# -------- Decision variables --------
+var mb{PROD,PER,DEAL} binary;                 # deal m runs for j in t
+var pd{PROD,PER} >= 0;                        # deal unit price
+var xd{SEG,PROD,PER,DEAL} binary;             # segment takes the deal
+var gd{SEG,PROD,PER,DEAL} >= 0;               # pd * xd
+var wd{PROD,PER,DEAL} >= 0;                   # pd * mb
+
+var bon{BUNDLE,PER} binary;                   # bundle b runs in t
+var pb{BUNDLE,PER} >= 0;                      # bundle price
+var xb{SEG,BUNDLE,PER} binary;                # segment takes the bundle
+var gb{SEG,BUNDLE,PER} >= 0;                  # pb * xb
+var wb{BUNDLE,PER} >= 0;                      # pb * bon
+
+# Chosen surplus of segment i in period t (unit options, deals
+# and bundles)
+var Surplus{i in SEG, t in PER} =
+    sum{k in PROD} (alpha[i,k,t] * x[i,k,t] - g[i,k,t])
+  + sum{k in PROD, m in DEAL} (A[i,k,t,m] * xd[i,k,t,m] - qty[m] * gd[i,k,t,m])
+  + sum{b in BUNDLE} (AB[i,b,t] * xb[i,b,t] - gb[i,b,t]);
+
+# ============================================================
+# Objective: APO-1 profit plus deal and bundle revenue
+# ============================================================
+maximize Profit_Multibuy:
+    sum{t in PER, i in SEG, b in BUNDLE} s[i] * gb[i,b,t]
+  + sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+      + sum{i in SEG, m in DEAL} s[i] * qty[m] * gd[i,j,t,m]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) At most one deal per listed product and period
+subject to OneDeal{j in PROD, t in PER}:
+    sum{m in DEAL} mb[j,t,m] <= deal_ok[j] * z[j];
+
+# 2) The deal is cheaper per unit than the unit price
+subject to DealBelowUnit{j in PROD, t in PER}:
+    pd[j,t] <= p[j,t];
+
+# 3) Bundles need every component listed and undercut the
+#    components bought at unit prices
+subject to BundleListed{b in BUNDLE, j in PROD, t in PER: bqty[b,j] > 0}:
+    bon[b,t] <= z[j];
+
+subject to BundleBelowUnit{b in BUNDLE, t in PER}:
+    pb[b,t] <= sum{j in PROD} bqty[b,j] * p[j,t];
+
+# 4) One choice per segment, deals and bundles included
+subject to SingleChoice_MB{i in SEG, t in PER}:
+    sum{k in CHOICE} x[i,k,t] + sum{k in PROD, m in DEAL} xd[i,k,t,m]
+  + sum{b in BUNDLE} xb[i,b,t] = 1;
+
+subject to DealRequiresOffer{i in SEG, j in PROD, t in PER, m in DEAL}:
+    xd[i,j,t,m] <= mb[j,t,m];
+
+subject to BundleRequiresOffer{i in SEG, b in BUNDLE, t in PER}:
+    xb[i,b,t] <= bon[b,t];
+
+# 5) Units sold include deal baskets and bundle components
+subject to DemandDef_MB{j in PROD, t in PER}:
+    d[j,t] = sum{i in SEG} s[i] * (x[i,j,t] + sum{m in DEAL} qty[m] * xd[i,j,t,m]
+                                  + sum{b in BUNDLE} bqty[b,j] * xb[i,b,t]);
+
+# 6) Baskets can lift period volume up to basket_max-fold
+subject to OrderCap_MB{j in PROD, t in PER}:
+    u[j,t] <= y[j,t] * ((card(PER) - ord(t) + 1) * S_total * basket_max[j]);
+
+# 7) Linearization of gd, wd, gb and wb
+subject to gd_up1{i in SEG, j in PROD, t in PER, m in DEAL}:
+    gd[i,j,t,m] <= p_ub[j,t] * xd[i,j,t,m];
+
+subject to gd_up2{i in SEG, j in PROD, t in PER, m in DEAL}:
+    gd[i,j,t,m] <= pd[j,t];
+
+subject to gd_low{i in SEG, j in PROD, t in PER, m in DEAL}:
+    gd[i,j,t,m] >= pd[j,t] - p_ub[j,t] * (1 - xd[i,j,t,m]);
+
+subject to wd_up1{j in PROD, t in PER, m in DEAL}:
+    wd[j,t,m] <= p_ub[j,t] * mb[j,t,m];
+
+subject to wd_up2{j in PROD, t in PER, m in DEAL}:
+    wd[j,t,m] <= pd[j,t];
+
+subject to wd_low{j in PROD, t in PER, m in DEAL}:
+    wd[j,t,m] >= pd[j,t] - p_ub[j,t] * (1 - mb[j,t,m]);
+
+subject to gb_up1{i in SEG, b in BUNDLE, t in PER}:
+    gb[i,b,t] <= pb_ub[b,t] * xb[i,b,t];
+
+subject to gb_up2{i in SEG, b in BUNDLE, t in PER}:
+    gb[i,b,t] <= pb[b,t];
+
+subject to gb_low{i in SEG, b in BUNDLE, t in PER}:
+    gb[i,b,t] >= pb[b,t] - pb_ub[b,t] * (1 - xb[i,b,t]);
+
+subject to wb_up1{b in BUNDLE, t in PER}:
+    wb[b,t] <= pb_ub[b,t] * bon[b,t];
+
+subject to wb_up2{b in BUNDLE, t in PER}:
+    wb[b,t] <= pb[b,t];
+
+subject to wb_low{b in BUNDLE, t in PER}:
+    wb[b,t] >= pb[b,t] - pb_ub[b,t] * (1 - bon[b,t]);
+
+# 8) Max-surplus choice over unit options, deals and bundles
+subject to NonNegUtility_MB{i in SEG, t in PER}:
+    Surplus[i,t] >= 0;
+
+subject to UtilityChoice_MB{i in SEG, t in PER, j in PROD}:
+    Surplus[i,t] >= alpha[i,j,t] * z[j] - w[j,t];
+
+subject to DealChoice{i in SEG, t in PER, j in PROD, m in DEAL}:
+    Surplus[i,t] >= A[i,j,t,m] * mb[j,t,m] - qty[m] * wd[j,t,m];
+
+subject to BundleChoice{i in SEG, t in PER, b in BUNDLE}:
+    Surplus[i,t] >= AB[i,b,t] * bon[b,t] - wb[b,t];
+
+drop SingleChoice;  drop DemandDef;  drop OrderCap;
+drop NonNegUtility;  drop UtilityChoice;
+objective Profit_Multibuy;
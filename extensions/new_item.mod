@@ -0,0 +1,45 @@
+# ============================================================
+# APO-1 extension: New-item introduction simulator
+# Compares the optimized plan without and with a candidate
+# product and reports its own sales, units cannibalized from
+# incumbents, net category lift and the inventory investment it
+# requires.
+#
+# The candidate has no history, so its reservation prices are a
+# cold-start estimate from analog incumbents: the similarity-
+# weighted analog alpha, shifted by its price positioning and
+# scaled by a launch ramp while shoppers get to know it (e.g.
+# the adoption share N(t)/m of a bass_diffusion forecast):
+#
+#   alpha[i,new,t] = ramp[t] * (1 + premium) * sum_a wt[a] alpha[i,a,t]
+#
+# The run script writes these over the candidate's alpha and
+# p_ub rows, so the choice model decides its sales and
+# cannibalization.
+#
+# Driven by extensions/new_item.run.
+# ============================================================
+
+param new_item symbolic in PROD;          # candidate product
+
+set INCUMBENT := PROD diff {new_item};
+
+# -------- Cold-start reservation prices --------
+set ANALOG within INCUMBENT;              # comparable listed items
+param an_wt{ANALOG} >= 0;                 # similarity weights
+param ni_premium > -1 default 0;          # value vs. analogs (+10% = 0.10)
+param ni_ramp{PER} >= 0, <= 1 default 1;  # launch ramp
+
+check: sum{a in ANALOG} an_wt[a] > 0;
+
+param ni_alpha{i in SEG, t in PER} :=
+    ni_ramp[t] * (1 + ni_premium)
+  * sum{a in ANALOG} an_wt[a] * alpha[i,a,t] / sum{a in ANALOG} an_wt[a];
+
+# -------- Scenario results (filled in by the run script) --------
+param base_units{INCUMBENT} default 0;    # incumbent units without new item
+param base_profit default 0;
+
+param with_units{PROD} default 0;         # units with new item listed
+param with_profit default 0;
+param new_invest default 0;               # procurement spend on new item
@@ -0,0 +1,70 @@
+# ============================================================
+# Standalone model: One-shot seasonal buy with salvage markets
+# Single-period newsvendor for one-time buys (Halloween,
+# Christmas). The buy is decided per size, allocated to stores
+# along each store's size curve, and sold against scenario
+# demand. After the season leftovers go to salvage channels
+# (outlet, jobber, donation, ...) with per-unit values and
+# per-channel capacity; anything beyond that is scrapped.
+#
+#   model extensions/oneshot_buy.mod;
+#   data extensions/oneshot_buy.dat;
+#   solve;  display Q, A;
+# ============================================================
+
+set PROD;                                   # styles
+set SIZE;
+set STORE;
+set SCEN;                                   # season demand scenarios
+set CHAN;                                   # salvage channels
+
+param prob{SCEN} >= 0;
+check: abs(sum{k in SCEN} prob[k] - 1) < 1e-6;
+
+param demand{PROD,STORE,SCEN} >= 0;         # style units, all sizes
+param chain_curve{PROD,SIZE} >= 0;          # size shares
+param curve{j in PROD, z in SIZE, st in STORE} >= 0 default chain_curve[j,z];
+check{j in PROD, st in STORE}: abs(sum{z in SIZE} curve[j,z,st] - 1) < 1e-6;
+
+param price{PROD} >= 0;
+param cost{PROD} >= 0;
+param min_buy{PROD} >= 0 default 0;         # vendor minimum per style
+
+param salv{PROD,CHAN} >= 0;                 # value per unit salvaged
+param chan_cap{CHAN} >= 0 default Infinity; # units the channel takes
+
+# -------- Decision variables --------
+var Q{PROD,SIZE} >= 0;                       # buy
+var A{PROD,SIZE,STORE} >= 0;                 # store allocation
+var Sold{j in PROD, z in SIZE, st in STORE, k in SCEN}
+    >= 0, <= curve[j,z,st] * demand[j,st,k];
+var Salv{PROD,SIZE,STORE,SCEN,CHAN} >= 0;
+
+# ============================================================
+# Objective: expected season profit
+# ============================================================
+maximize Expected_Profit:
+    sum{k in SCEN} prob[k] * (
+        sum{j in PROD, z in SIZE, st in STORE} (
+            price[j] * Sold[j,z,st,k]
+          + sum{ch in CHAN} salv[j,ch] * Salv[j,z,st,k,ch]))
+  - sum{j in PROD, z in SIZE} cost[j] * Q[j,z];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) The buy is fully allocated
+subject to Allocate{j in PROD, z in SIZE}:
+    sum{st in STORE} A[j,z,st] = Q[j,z];
+
+subject to MinBuy{j in PROD: min_buy[j] > 0}:
+    sum{z in SIZE} Q[j,z] >= min_buy[j];
+
+# 2) Sales and salvage out of each store's allocation
+subject to Stock{j in PROD, z in SIZE, st in STORE, k in SCEN}:
+    Sold[j,z,st,k] + sum{ch in CHAN} Salv[j,z,st,k,ch] <= A[j,z,st];
+
+# 3) Salvage channel capacity per scenario
+subject to ChanCap{ch in CHAN, k in SCEN: chan_cap[ch] < Infinity}:
+    sum{j in PROD, z in SIZE, st in STORE} Salv[j,z,st,k,ch] <= chan_cap[ch];
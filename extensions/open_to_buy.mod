@@ -0,0 +1,61 @@
+# ============================================================
+# APO-1 extension: Open-to-buy (OTB) budgets
+# Converts a category sales plan and a weeks-of-supply target
+# into monthly buy budgets (at cost) and caps the receipts that
+# APO-1 may order within each month:
+#
+#   OTB = planned sales + planned EOM stock - BOM stock - on order
+#
+# Categories come from categories.mod. Months and their lengths
+# (MONTH, month_of, weeks) are normally the fiscal months that
+# fiscal_buckets.run writes from fiscal_calendar.mod; they can
+# also be given directly in the data.
+#
+#   model APO-1.mod;  model extensions/categories.mod;
+#   model extensions/fiscal_calendar.mod;
+#   model extensions/open_to_buy.mod;
+#   data "Sample 2.dat";  data extensions/categories.dat;
+#   data extensions/fiscal_calendar.dat;
+#   include extensions/fiscal_buckets.run;
+#   data (cal_file);  data extensions/open_to_buy.dat;
+#   solve;  display otb, Receipts;
+# ============================================================
+
+set MONTH ordered;                        # budget months
+
+param month_of{PER} symbolic in MONTH;
+
+# Weeks in each month (defaults to the planning periods in it,
+# which understates months the horizon only partly covers)
+param weeks{m in MONTH} > 0 default card({t in PER: month_of[t] = m});
+
+# -------- Plan inputs (at cost) --------
+param sales_plan{CAT,MONTH} >= 0;         # planned sales
+param wos_target{CAT} >= 0;               # target weeks of supply at EOM
+param bom_open{CAT} >= 0 default 0;       # stock at start of first month
+param on_order{CAT,MONTH} >= 0 default 0; # receipts already committed
+
+# Planned end-of-month stock: target WOS of next month's sales rate
+param eom_plan{cat in CAT, m in MONTH} :=
+    wos_target[cat] *
+    (if m = last(MONTH)
+     then sales_plan[cat,m] / weeks[m]
+     else sales_plan[cat,next(m)] / weeks[next(m)]);
+
+param bom_plan{cat in CAT, m in MONTH} :=
+    if m = first(MONTH) then bom_open[cat] else eom_plan[cat,prev(m)];
+
+param otb{cat in CAT, m in MONTH} :=
+    max(0, sales_plan[cat,m] + eom_plan[cat,m] - bom_plan[cat,m] - on_order[cat,m]);
+
+# Approved budget; defaults to the computed OTB
+param otb_approved{cat in CAT, m in MONTH} >= 0 default otb[cat,m];
+
+# -------- Reported receipts --------
+var Receipts{cat in CAT, m in MONTH} =
+    sum{j in PROD, t in PER: cat_of[j] = cat and month_of[t] = m} c[j,t] * u[j,t];
+
+# Receipts at cost stay within the approved open-to-buy
+subject to OTBCap{cat in CAT, m in MONTH}:
+    sum{j in PROD, t in PER: cat_of[j] = cat and month_of[t] = m} c[j,t] * u[j,t]
+    <= otb_approved[cat,m];
@@ -0,0 +1,58 @@
+# ============================================================
+# APO-1 extension: Pack hierarchy (each / inner / case / pallet)
+# APO-1 demand and inventory stay in eaches. The pack hierarchy
+# itself (per_each, order_uom, cube_each) comes from packs.mod,
+# shared with the other modules that work in packs; the explicit
+# conversions tie APO-1's quantities to it:
+#
+#   ordering  - orders are whole units of order_uom[j]
+#   receiving - each product's receipt occupies whole pallets,
+#               capped per period (dock capacity)
+#   storage   - stock on hand in pallet equivalents and cube,
+#               capped by the store room
+#
+# Whole packs rarely match demand exactly, so the zero ending
+# stock of APO-1 becomes "less than one order unit".
+#
+#   model APO-1.mod;  model extensions/packs.mod;
+#   model extensions/pack_hierarchy.mod;
+#   data "Sample 2.dat";  data extensions/packs.dat;
+#   data extensions/pack_hierarchy.dat;
+#   solve;  display n_order, n_pallet;
+# ============================================================
+
+param dock_pallets{PER} >= 0 default Infinity;    # receivable pallets
+param store_pallets >= 0 default Infinity;        # storage, pallet eq.
+param store_cube >= 0 default Infinity;           # storage, cube
+
+# -------- Decision variables --------
+var n_order{PROD,PER} integer >= 0;               # in order_uom
+var n_pallet{PROD,PER} integer >= 0;              # pallets received
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Orders in whole order units
+subject to OrderPacks{j in PROD, t in PER}:
+    u[j,t] = order_each[j] * n_order[j,t];
+
+# 2) Receipts occupy whole pallets
+subject to ReceiptPallets{j in PROD, t in PER}:
+    n_pallet[j,t] >= to_uom[j,"pallet"] * u[j,t];
+
+subject to DockCap{t in PER: dock_pallets[t] < Infinity}:
+    sum{j in PROD} n_pallet[j,t] <= dock_pallets[t];
+
+# 3) Storage in pallet equivalents and cube
+subject to StorePallets{t in PER: store_pallets < Infinity}:
+    sum{j in PROD} to_uom[j,"pallet"] * I[j,t] <= store_pallets;
+
+subject to StoreCube{t in PER: store_cube < Infinity}:
+    sum{j in PROD} cube_each[j] * I[j,t] <= store_cube;
+
+# 4) Ending stock below one order unit
+subject to EndInvPack{j in PROD, t in last(PER)}:
+    I[j,t] <= order_each[j] - 1;
+
+drop EndInvZero;
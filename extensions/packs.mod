@@ -0,0 +1,41 @@
+# ============================================================
+# APO-1 extension: Pack hierarchy units (shared)
+# Each product has a pack hierarchy each / inner / case / pallet
+# with per_each[j,k] eaches per unit of pack level k, an order
+# unit, and the cube and weight of one each. Demand and stock
+# stay in eaches everywhere; modules that order, ship or store
+# in packs convert through these parameters:
+#
+#   pack_hierarchy  - whole order units, pallets at the dock,
+#                     pallet and cube storage
+#   lot_sizing      - vendor capacity in pallets, lot caps in
+#                     order units
+#   bracket_pricing - bracket minimums in order units
+#   containers      - container cube and weight per each
+#   allocation      - shipping pack = order unit (standalone;
+#                     it loads this file itself)
+#
+# Load it after APO-1.mod and before any of them.
+#
+#   model APO-1.mod;  model extensions/packs.mod;
+#   model extensions/pack_hierarchy.mod;
+#   data "Sample 2.dat";  data extensions/packs.dat;
+#   data extensions/pack_hierarchy.dat;
+# ============================================================
+
+set UOM ordered := {"each", "inner", "case", "pallet"};
+
+param per_each{PROD,UOM} integer >= 1;            # eaches per unit
+param order_uom{PROD} symbolic in UOM default "case";
+param cube_each{PROD} >= 0 default 0;             # cube per each
+param wt_each{PROD} >= 0 default 0;               # weight per each
+
+check{j in PROD}: per_each[j,"each"] = 1;
+check{j in PROD, k in UOM: ord(k) > 1}:
+    per_each[j,k] mod per_each[j,prev(k)] = 0;
+
+# Conversion from eaches to pack level k
+param to_uom{j in PROD, k in UOM} := 1 / per_each[j,k];
+
+# Eaches per order unit
+param order_each{j in PROD} := per_each[j,order_uom[j]];
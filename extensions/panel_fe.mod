@@ -0,0 +1,116 @@
+# ============================================================
+# Standalone model: Panel elasticity with SKU and store effects
+# Log-log demand on a SKU x store x week panel:
+#
+#   log q[j,s,w] = a[j] + b[s] + elast * log p[j,s,w] + e
+#
+# Both sets of fixed effects are removed by the two-way within
+# transformation, so elast comes from price variation across
+# stores and weeks within a SKU. Weeks flagged out of stock
+# (in_stock = 0) are dropped because their sales are censored;
+# the within transformation is then done by alternating SKU and
+# store demeaning (one sweep is exact for a balanced panel).
+# Standard errors are clustered by SKU, with the usual small-
+# sample correction G/(G-1) * (N-1)/(N-K).
+#
+# panel_fe_drift.run compares the estimate with the one saved by
+# the previous run (pf_fit_file) and flags drift when they differ
+# by more than pf_drift_z combined standard errors.
+#
+#   model extensions/panel_fe.mod;
+#   data extensions/panel_fe.dat;
+#   display elast, se_cl, ci_lo, ci_hi;
+#   include extensions/panel_fe_drift.run;      # optional
+# ============================================================
+
+set PROD;
+set STORE;
+set WEEK;
+
+param qty{PROD,STORE,WEEK} >= 0;
+param price{PROD,STORE,WEEK} > 0;
+param in_stock{PROD,STORE,WEEK} binary default 1;
+
+param z_crit > 0 default 1.96;
+
+set OBS := {j in PROD, s in STORE, w in WEEK: in_stock[j,s,w] = 1};
+
+check{(j,s,w) in OBS}: qty[j,s,w] > 0;
+
+param n_obs := card(OBS);
+param n_par := 1 + (card(PROD) - 1) + (card(STORE) - 1);
+param n_cl := card(PROD);
+check: n_cl >= 2 and n_obs > n_par;
+
+param n_j{j in PROD} := card({s in STORE, w in WEEK: (j,s,w) in OBS});
+param n_s{s in STORE} := card({j in PROD, w in WEEK: (j,s,w) in OBS});
+check{j in PROD}: n_j[j] > 0;
+check{s in STORE}: n_s[s] > 0;
+
+param fe_sweeps integer >= 1 default
+    if n_obs = card(PROD) * card(STORE) * card(WEEK) then 1 else 50;
+
+param ly{(j,s,w) in OBS} := log(qty[j,s,w]);
+param lp{(j,s,w) in OBS} := log(price[j,s,w]);
+
+# -------- Within transformation --------
+# Alternating demeaning keeps only the accumulated SKU effect
+# A[j] and store effect B[s]: sweep r removes the SKU means
+# (A_r = ybar_j - mean over j's rows of B_{r-1}), then the store
+# means (B_r = ybar_s - mean over s's rows of A_r). Substituting
+# A_r gives B_r from B_{r-1} through the store-by-store weights
+# wss, so a sweep costs O(stores^2) rather than a pass over the
+# panel per observation; y - A - B is formed once at the end.
+param cnt{j in PROD, s in STORE} := card({w in WEEK: (j,s,w) in OBS});
+
+param wss{s in STORE, s2 in STORE} :=
+    sum{j in PROD: cnt[j,s] > 0 and cnt[j,s2] > 0}
+        cnt[j,s] * cnt[j,s2] / (n_j[j] * n_s[s]);
+
+param ly_j{j in PROD} := sum{s in STORE, w in WEEK: (j,s,w) in OBS} ly[j,s,w] / n_j[j];
+param ly_s{s in STORE} := sum{j in PROD, w in WEEK: (j,s,w) in OBS} ly[j,s,w] / n_s[s];
+param lp_j{j in PROD} := sum{s in STORE, w in WEEK: (j,s,w) in OBS} lp[j,s,w] / n_j[j];
+param lp_s{s in STORE} := sum{j in PROD, w in WEEK: (j,s,w) in OBS} lp[j,s,w] / n_s[s];
+
+param yB{r in 0..fe_sweeps, s in STORE} :=
+    if r = 0 then 0
+    else ly_s[s] - sum{j in PROD} cnt[j,s] * ly_j[j] / n_s[s]
+       + sum{s2 in STORE} wss[s,s2] * yB[r-1,s2];
+param xB{r in 0..fe_sweeps, s in STORE} :=
+    if r = 0 then 0
+    else lp_s[s] - sum{j in PROD} cnt[j,s] * lp_j[j] / n_s[s]
+       + sum{s2 in STORE} wss[s,s2] * xB[r-1,s2];
+
+param yA{j in PROD} :=
+    ly_j[j] - sum{s in STORE} cnt[j,s] * yB[fe_sweeps - 1,s] / n_j[j];
+param xA{j in PROD} :=
+    lp_j[j] - sum{s in STORE} cnt[j,s] * xB[fe_sweeps - 1,s] / n_j[j];
+
+param yt{(j,s,w) in OBS} := ly[j,s,w] - yA[j] - yB[fe_sweeps,s];
+param xt{(j,s,w) in OBS} := lp[j,s,w] - xA[j] - xB[fe_sweeps,s];
+
+param sxx := sum{(j,s,w) in OBS} xt[j,s,w]^2;
+check: sxx > 0;
+
+# -------- Estimate --------
+param elast := sum{(j,s,w) in OBS} xt[j,s,w] * yt[j,s,w] / sxx;
+
+param resid{(j,s,w) in OBS} := yt[j,s,w] - elast * xt[j,s,w];
+
+# Cluster-robust variance (clusters = SKUs)
+param score{j in PROD} :=
+    sum{s in STORE, w in WEEK: (j,s,w) in OBS} xt[j,s,w] * resid[j,s,w];
+param se_cl := sqrt(n_cl / (n_cl - 1) * (n_obs - 1) / (n_obs - n_par)
+                    * sum{j in PROD} score[j]^2) / sxx;
+
+param ci_lo := elast - z_crit * se_cl;
+param ci_hi := elast + z_crit * se_cl;
+
+# -------- Drift against the previous estimate (panel_fe_drift.run) --------
+param pf_fit_file symbolic default "panel_fe_fit.dat";
+param pf_prev_elast default 0;
+param pf_prev_se >= 0 default 0;
+param pf_prev_obs integer >= 0 default 0;    # 0 = no previous estimate
+param pf_drift_z > 0 default 2;
+
+param pf_z := (elast - pf_prev_elast) / max(1e-9, sqrt(se_cl^2 + pf_prev_se^2));
@@ -0,0 +1,29 @@
+# ============================================================
+# APO-1 extension: Pareto front, profit vs. inventory held
+# Epsilon-constraint method: profit is maximized while total
+# end-of-period inventory is capped at successively tighter
+# levels between the profit-optimal plan and the minimum-stock
+# plan. Dominated points are dropped from the report.
+#
+# Driven by extensions/pareto.run.
+# ============================================================
+
+var StockHeld = sum{j in PROD, t in PER} I[j,t];
+
+param eps default Infinity;              # current inventory cap
+
+subject to StockCap:
+    sum{j in PROD, t in PER} I[j,t] <= eps;
+
+minimize MinStock:
+    sum{j in PROD, t in PER} I[j,t];
+
+objective Profit;
+
+# -------- Front (filled in by the run script) --------
+param n_pts integer >= 2 default 6;
+set PTS := 1..n_pts;
+
+param front_profit{PTS};
+param front_stock{PTS};
+param dominated{PTS} binary default 0;
@@ -0,0 +1,62 @@
+# ============================================================
+# APO-1 extension: Phantom-inventory detection
+# Flags products whose recent sales imply an empty shelf even
+# though the system still reports stock, and feeds the corrected
+# availability into demand (choice) and replenishment (balance).
+#
+# Load after APO-1.mod:
+#   model APO-1.mod;  model extensions/phantom_inventory.mod;
+#   data "Sample 2.dat";  data extensions/phantom_inventory.dat;
+#   solve;  display phantom, onhand_eff;
+#
+# To detect on history cleaned by demand_anomalies, read the file
+# its run script writes over the sample history:
+#   reset data HIST, sales_hist;  data sales_clean.dat;
+# ============================================================
+
+# -------- Sales history --------
+set HIST ordered;                   # past periods, oldest first
+
+param sales_hist{PROD,HIST} >= 0;   # observed unit sales
+param onhand_sys{PROD} >= 0;        # system on-hand at start of horizon
+
+# Detection rule: no sales in the last zero_run periods while the
+# product used to sell at least min_rate units per period.
+param zero_run integer >= 1 default 3;
+param min_rate >= 0 default 1;
+
+param n_base := card(HIST) - zero_run;
+check: n_base >= 1;
+
+param base_rate{j in PROD} :=
+    (sum{h in HIST: ord(h) <= n_base} sales_hist[j,h]) / n_base;
+
+param recent_sales{j in PROD} :=
+    sum{h in HIST: ord(h) > n_base} sales_hist[j,h];
+
+param phantom{j in PROD} binary :=
+    if onhand_sys[j] > 0 and base_rate[j] >= min_rate and recent_sales[j] = 0
+    then 1 else 0;
+
+# -------- Corrected availability --------
+# Phantom stock is treated as not on the shelf: it cannot sell and
+# cannot cover demand until a replenishment restocks the shelf.
+param shelf_avail{j in PROD} binary := 1 - phantom[j];
+param onhand_eff{j in PROD} := if phantom[j] = 1 then 0 else onhand_sys[j];
+
+# Opening stock that is not sold within the horizon is written off
+# (sunk cost), which keeps EndInvZero feasible for unlisted products.
+var wo{PROD} >= 0;
+
+subject to WriteOffCap{j in PROD}:
+    wo[j] <= onhand_eff[j];
+
+# Replaces APO-1's zero starting inventory with the corrected on-hand
+subject to InvBal_First_Corrected{j in PROD, t in first(PER)}:
+    I[j,t] = onhand_eff[j] - wo[j] + u[j,t] - d[j,t];
+
+# An empty shelf sells nothing in the first period unless restocked
+subject to ShelfAvailable{i in SEG, j in PROD, t in first(PER)}:
+    x[i,j,t] <= shelf_avail[j] + y[j,t];
+
+drop InvBal_First;
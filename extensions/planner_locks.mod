@@ -0,0 +1,40 @@
+# ============================================================
+# APO-1 extension: Planner overrides and locks
+# Optimization runs in rounds. After each round planners may
+# lock listings (lock_z) and prices (lock_p); a negative value
+# means free. The next round re-optimizes everything still free
+# around the locks. planner_locks.run reads one override file
+# per round, so any number of rounds can follow the preliminary
+# plan, and reports the profit cost of each round's locks.
+#
+#   ampl extensions/planner_locks.run
+# ============================================================
+
+param lock_z{PROD} default -1;              # 0/1 locks the listing
+param lock_p{PROD,PER} default -1;          # >= 0 locks the price
+
+check{j in PROD}: lock_z[j] in {-1, 0, 1};
+check{j in PROD, t in PER: lock_p[j,t] >= 0}:
+    lock_p[j,t] <= p_ub[j,t] and lock_z[j] <> 0;
+
+set ROUND ordered default {};
+param override_file{ROUND} symbolic;        # one data file per round
+
+# -------- Filled by planner_locks.run --------
+param round_profit{0..card(ROUND)} default 0;
+param prev_z{PROD} default 0;
+param prev_p{PROD,PER} default 0;
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to LockListing{j in PROD: lock_z[j] >= 0}:
+    z[j] = lock_z[j];
+
+subject to LockPrice{j in PROD, t in PER: lock_p[j,t] >= 0}:
+    p[j,t] = lock_p[j,t];
+
+# A price lock implies the item stays listed
+subject to LockPriceListed{j in PROD: exists{t in PER} lock_p[j,t] >= 0}:
+    z[j] = 1;
@@ -0,0 +1,54 @@
+# ============================================================
+# Standalone model: Inventory policy simulation on history
+# Replays historical demand against three periodic-review
+# policies and reports fill rate, average inventory, orders
+# placed and waste (units expiring after life[j] periods, sold
+# FIFO) for each:
+#
+#   sS      - when inventory position <= s, order up to S
+#   base    - every period, order up to the base-stock level
+#   minmax  - when inventory position < min, order up to max
+#             in whole case packs
+#
+#   model extensions/policy_sim.mod;
+#   data extensions/policy_sim.dat;
+#   include extensions/policy_sim.run;
+# ============================================================
+
+set PROD;
+set HIST ordered;                              # replayed periods
+set POLICY := {"sS", "base", "minmax"};
+
+param demand_hist{PROD,HIST} >= 0;
+param lead{PROD} integer >= 0 default 1;       # periods until receipt
+param life{PROD} integer >= 1 default 52;      # shelf life in periods
+param init_inv{PROD} >= 0 default 0;
+
+param s_lvl{PROD} >= 0;                        # (s,S)
+param S_lvl{j in PROD} >= s_lvl[j];
+param base_lvl{PROD} >= 0;                     # base stock
+param min_lvl{PROD} >= 0;                      # min/max
+param max_lvl{j in PROD} >= min_lvl[j];
+param case_pack{PROD} integer >= 1 default 1;
+
+# -------- Simulation state (updated by the run script) --------
+param max_life := max{j in PROD} life[j];
+param max_lead := max(1, max{j in PROD} lead[j]);
+
+param oh{PROD, 0..max_life-1} default 0;       # on hand by age
+param pipe{PROD, 1..max_lead} default 0;       # arriving in k periods
+param ip default 0;                            # inventory position
+param q default 0;                             # order quantity
+param rem default 0;                           # demand left to fill
+param take default 0;
+
+# -------- Results --------
+param filled{POLICY,PROD} default 0;
+param inv_sum{POLICY,PROD} default 0;
+param n_orders{POLICY,PROD} default 0;
+param wasted{POLICY,PROD} default 0;
+
+param total_demand{j in PROD} := sum{t in HIST} demand_hist[j,t];
+param fill_rate{pol in POLICY, j in PROD} :=
+    if total_demand[j] > 0 then filled[pol,j] / total_demand[j] else 1;
+param avg_inv{pol in POLICY, j in PROD} := inv_sum[pol,j] / card(HIST);
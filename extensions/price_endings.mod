@@ -0,0 +1,39 @@
+# ============================================================
+# APO-1 extension: Price endings (psychological pricing)
+# Prices are restricted to the allowed price points of their
+# band, e.g. x.x9 below 2.00 and x.99 / x.49 above, so the
+# optimizer's price is already final and satisfies every other
+# constraint. A price point is unit * n + ending, for the band
+# [band_lo, band_hi) it falls in. Load a different band table
+# per country for country-specific charm pricing.
+#
+#   model APO-1.mod;  model extensions/price_endings.mod;
+#   data "Sample 2.dat";  data extensions/price_endings.dat;
+#   solve;  display p;
+# ============================================================
+
+set BAND;
+
+param band_lo{BAND} >= 0;
+param band_hi{b in BAND} > band_lo[b];
+param unit{BAND} > 0;                      # step between price points
+set ENDING{BAND};                          # endings, e.g. 0.99 0.49
+
+check{b in BAND, e in ENDING[b]}: 0 <= e < unit[b];
+
+# Allowed price points of product j in period t
+set CAND{j in PROD, t in PER} :=
+    setof{b in BAND, n in 0..floor(band_hi[b] / unit[b]), e in ENDING[b]:
+          band_lo[b] <= n * unit[b] + e < band_hi[b] and n * unit[b] + e <= p_ub[j,t]}
+    round(n * unit[b] + e, 2);
+
+check{j in PROD, t in PER}: card(CAND[j,t]) >= 1;
+
+var pe_v{j in PROD, t in PER, CAND[j,t]} binary;   # price point chosen
+
+# A listed product takes exactly one allowed price point
+subject to OnePricePoint{j in PROD, t in PER}:
+    sum{q in CAND[j,t]} pe_v[j,t,q] = z[j];
+
+subject to PriceOnPoint{j in PROD, t in PER}:
+    p[j,t] = sum{q in CAND[j,t]} q * pe_v[j,t,q];
@@ -0,0 +1,41 @@
+# ============================================================
+# APO-1 extension: Confidence gating of price changes
+# Reservation prices are estimates with standard error
+# alpha_sd. A recommended price change for product j is pushed
+# only if its profit gain over the current price holds up under
+# that uncertainty: price_gating.run redraws alpha n_draw times
+# and, for each draw, values the current prices with only j
+# moved to the recommendation against the current prices
+# unchanged. The change passes when
+#
+#   mean(gain) - z_gate * sd(gain) > min_gain
+#
+# and is otherwise suppressed (the current price is kept).
+#
+#   model APO-1.mod;  model extensions/price_gating.mod;
+#   data "Sample 2.dat";  data extensions/price_gating.dat;
+#   include extensions/price_gating.run;
+# ============================================================
+
+param cur_price{PROD,PER} >= 0;                  # 0 = not listed today
+param alpha_sd{SEG,PROD,PER} >= 0 default 0;     # std. error of alpha
+
+param n_draw integer >= 2 default 20;
+param z_gate >= 0 default 1.28;                  # ~90% one-sided
+param min_gain >= 0 default 0;
+param chg_tol >= 0 default 0.005;                # smaller moves are no change
+
+param cur_z{j in PROD} binary := if max{t in PER} cur_price[j,t] > 0 then 1 else 0;
+
+# -------- Filled by price_gating.run --------
+param alpha0{SEG,CHOICE,PER};
+param rec_p{PROD,PER} default 0;
+param gain{PROD,1..n_draw} default 0;
+param gain_mean{j in PROD} := sum{k in 1..n_draw} gain[j,k] / n_draw;
+param gain_sd{j in PROD} :=
+    sqrt(sum{k in 1..n_draw} (gain[j,k] - gain_mean[j])^2 / (n_draw - 1));
+param changed{j in PROD} binary default 0;
+param passed{j in PROD} binary :=
+    if changed[j] = 1 and gain_mean[j] - z_gate * gain_sd[j] > min_gain then 1 else 0;
+param final_p{j in PROD, t in PER} :=
+    if passed[j] = 1 then rec_p[j,t] else cur_price[j,t];
@@ -0,0 +1,57 @@
+# ============================================================
+# Standalone model: Cross-border price harmonization
+# Projects per-country price recommendations (local currency,
+# VAT included) onto the closest price set that satisfies rules
+# of the form
+#
+#   price in A  <=  ratio * price in B     (after FX, net or gross)
+#
+# Deviation is measured relative to the recommendation. After a
+# solve, price_harmonization.run re-checks the prices with fx_new.
+#
+#   model extensions/price_harmonization.mod;
+#   data extensions/price_harmonization.dat;
+#   include extensions/price_harmonization.run;
+# ============================================================
+
+set COUNTRY;
+set PROD;
+
+param rec_price{COUNTRY,PROD} > 0;        # recommended local gross price
+param vat{COUNTRY} >= 0 default 0;        # VAT rate, e.g. 0.19
+param fx{COUNTRY} > 0;                    # base currency per local unit
+param fx_new{c in COUNTRY} > 0 default fx[c];  # rates for re-checking
+
+param band >= 0 default 0.25;             # max relative move from rec_price
+
+# Rules: (a, b) -> price_a <= ratio * price_b
+set RULE within {COUNTRY, COUNTRY};
+param ratio{RULE} > 0;
+param basis{RULE} symbolic in {"net", "gross"} default "net";
+
+# Local gross price -> base-currency price on the rule's basis
+param conv{(a,b) in RULE, cc in {a,b}} :=
+    fx[cc] / (if basis[a,b] = "net" then 1 + vat[cc] else 1);
+
+# -------- Decision Variables --------
+var price{cc in COUNTRY, j in PROD}
+    >= (1 - band) * rec_price[cc,j], <= (1 + band) * rec_price[cc,j];
+
+var dev{COUNTRY,PROD} >= 0;               # |price - rec| / rec
+
+# -------- Objective --------
+minimize Deviation:
+    sum{cc in COUNTRY, j in PROD} dev[cc,j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to DevUp{cc in COUNTRY, j in PROD}:
+    dev[cc,j] >= (price[cc,j] - rec_price[cc,j]) / rec_price[cc,j];
+
+subject to DevDown{cc in COUNTRY, j in PROD}:
+    dev[cc,j] >= (rec_price[cc,j] - price[cc,j]) / rec_price[cc,j];
+
+subject to Harmonize{(a,b) in RULE, j in PROD}:
+    conv[a,b,a] * price[a,j] <= ratio[a,b] * conv[a,b,b] * price[b,j];
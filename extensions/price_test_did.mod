@@ -0,0 +1,78 @@
+# ============================================================
+# Standalone model: Elasticity from controlled price tests
+# Difference-in-differences on a store panel. For each test the
+# treatment stores change the price, the control stores do not:
+#
+#   DiD_k  = mean_treat(dlog q) - mean_ctrl(dlog q)
+#   elast_k = DiD_k / dlog p_k
+#
+# with a normal-approximation confidence interval. Tests on the
+# same product are pooled by inverse variance, and the pooled
+# estimate overrides the observational elasticity.
+#
+#   model extensions/price_test_did.mod;
+#   data extensions/price_test_did.dat;
+#   display elast_test, ci_lo, ci_hi, elast;
+# ============================================================
+
+set PROD;                     # products
+set STORE;                    # store panel
+set TEST;                     # price tests
+
+param test_prod{TEST} symbolic in PROD;
+param treated{TEST,STORE} binary;           # 1 = treatment store
+param in_test{TEST,STORE} binary default 1; # store part of the test
+
+param p_pre{TEST} > 0;                      # price before the test
+param p_post{TEST} > 0;                     # test price (treatment)
+param q_pre{TEST,STORE} > 0;                # avg weekly units, pre
+param q_post{TEST,STORE} > 0;               # avg weekly units, post
+
+param obs_elast{PROD} default -1.5;         # observational estimate
+param z_crit > 0 default 1.96;              # 95% interval
+
+check{k in TEST}: p_post[k] <> p_pre[k];
+
+# -------- Panel summaries --------
+param dlq{k in TEST, st in STORE: in_test[k,st] = 1} :=
+    log(q_post[k,st]) - log(q_pre[k,st]);
+
+set TREAT{k in TEST} := {st in STORE: in_test[k,st] = 1 and treated[k,st] = 1};
+set CTRL{k in TEST}  := {st in STORE: in_test[k,st] = 1 and treated[k,st] = 0};
+
+check{k in TEST}: card(TREAT[k]) >= 2 and card(CTRL[k]) >= 2;
+
+param m_t{k in TEST} := sum{st in TREAT[k]} dlq[k,st] / card(TREAT[k]);
+param m_c{k in TEST} := sum{st in CTRL[k]}  dlq[k,st] / card(CTRL[k]);
+
+param v_t{k in TEST} :=
+    sum{st in TREAT[k]} (dlq[k,st] - m_t[k])^2 / (card(TREAT[k]) - 1);
+param v_c{k in TEST} :=
+    sum{st in CTRL[k]}  (dlq[k,st] - m_c[k])^2 / (card(CTRL[k]) - 1);
+
+# -------- Test-level estimates --------
+param dlp{k in TEST} := log(p_post[k] / p_pre[k]);
+
+param elast_test{k in TEST} := (m_t[k] - m_c[k]) / dlp[k];
+
+param se_test{k in TEST} :=
+    sqrt(v_t[k] / card(TREAT[k]) + v_c[k] / card(CTRL[k])) / abs(dlp[k]);
+
+param ci_lo{k in TEST} := elast_test[k] - z_crit * se_test[k];
+param ci_hi{k in TEST} := elast_test[k] + z_crit * se_test[k];
+
+# -------- Pooled estimate per product (inverse variance) --------
+set TESTED := setof{k in TEST} test_prod[k];
+
+param w_test{k in TEST} := 1 / max(se_test[k]^2, 1e-12);
+
+param elast_pooled{j in TESTED} :=
+    sum{k in TEST: test_prod[k] = j} w_test[k] * elast_test[k]
+  / sum{k in TEST: test_prod[k] = j} w_test[k];
+
+param se_pooled{j in TESTED} :=
+    1 / sqrt(sum{k in TEST: test_prod[k] = j} w_test[k]);
+
+# Experimental estimates take precedence where available
+param elast{j in PROD} :=
+    if j in TESTED then elast_pooled[j] else obs_elast[j];
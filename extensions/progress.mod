@@ -0,0 +1,25 @@
+# ============================================================
+# Progress reporting for long run scripts
+# A driver sets the prog_* parameters and includes
+# extensions/progress.run after each unit of work. The reporter
+# prints a progress line and rewrites status_file with the
+# latest state as one JSON object, so a terminal, a wrapper
+# script or a status page can all follow a run.
+#
+#   model extensions/progress.mod;
+#   let prog_t0 := time();
+#   ...  let prog_stage := "repair";  let prog_done := k;
+#        let prog_obj := f_best;  include extensions/progress.run;
+# ============================================================
+
+param status_file symbolic default "progress.json";
+param prog_quiet binary default 0;       # 1 = status file only
+
+param prog_stage symbolic default "start";
+param prog_done >= 0 default 0;          # items completed
+param prog_total >= 0 default 0;         # items planned (0 = unknown)
+param prog_obj default -Infinity;        # incumbent objective
+param prog_t0 default 0;                 # time() at start
+
+param prog_elapsed default 0;
+param prog_eta default -1;               # seconds left (-1 = unknown)
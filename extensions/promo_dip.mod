@@ -0,0 +1,43 @@
+# ============================================================
+# APO-1 extension: Post-promotion dip (pantry loading)
+# After a promoted period, shoppers who stocked up skip part of
+# their next purchases. For planned promotions promo[j,t] the
+# demand of product j in the following periods is reduced by
+# lagged dip terms:
+#
+#   dip[j,t] = sum_l dip_lag[j,l] * promo[j,t-l]
+#   d[j,t]   = (1 - dip[j,t]) * sum_i s[i] x[i,j,t]
+#
+# so replenishment does not over-order right after a promo.
+# Replaces DemandDef and the objective of APO-1.
+#
+#   model APO-1.mod;  model extensions/promo_dip.mod;
+#   data "Sample 2.dat";  data extensions/promo_dip.dat;
+#   solve;  display dip;
+# ============================================================
+
+param promo_lags integer >= 1 default 2;
+set PLAG := 1..promo_lags;
+
+param promo{PROD,PER} binary default 0;      # planned promotion calendar
+param dip_lag{PROD,PLAG} >= 0, < 1 default 0; # demand lost at lag l
+
+param dip{j in PROD, t in PER} :=
+    min(1, sum{l in PLAG: ord(t) - l >= 1} dip_lag[j,l] * promo[j, member(ord(t) - l, PER)]);
+
+# -------- Objective --------
+maximize Profit_Dip:
+    sum{t in PER, j in PROD} (
+        (1 - dip[j,t]) * sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# Demand net of the post-promo dip (replaces DemandDef)
+subject to DemandDef_Dip{j in PROD, t in PER}:
+    d[j,t] = (1 - dip[j,t]) * sum{i in SEG} s[i] * x[i,j,t];
+
+drop DemandDef;
+objective Profit_Dip;
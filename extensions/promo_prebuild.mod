@@ -0,0 +1,92 @@
+# ============================================================
+# Standalone model: Promotion pre-build planner
+# Schedules DC inflows and DC -> store pushes over the weeks
+# leading into a promotion. Store demand is the base forecast
+# times uplift[j] in promo weeks. Inflows are limited by DC
+# receiving capacity, pushes by weekly transport capacity per
+# store, and stock by DC and store storage, so the peak has to
+# be built up ahead of time. Unmet demand is lost at margin[j].
+# Inflows arrive lead weeks after they are placed; nothing can
+# be placed before the first week.
+#
+#   model extensions/promo_prebuild.mod;
+#   data extensions/promo_prebuild.dat;
+#   solve;  display Inflow, Push, Lost;
+# ============================================================
+
+set PROD;
+set STORE;
+set WEEK ordered;
+
+param promo{WEEK} binary default 0;
+param base{PROD,STORE,WEEK} >= 0;               # base demand forecast
+param uplift{PROD} >= 1 default 1;              # promo multiplier
+
+param demand{j in PROD, st in STORE, w in WEEK} :=
+    base[j,st,w] * (if promo[w] = 1 then uplift[j] else 1);
+
+param lead integer >= 0 default 1;              # inflow lead time, weeks
+param dc_init{PROD} >= 0 default 0;
+param store_init{PROD,STORE} >= 0 default 0;
+
+param dc_recv{WEEK} >= 0 default Infinity;      # units received per week
+param dc_space >= 0 default Infinity;           # DC stock, units
+param truck{STORE,WEEK} >= 0 default Infinity;  # units pushed per week
+param store_space{STORE} >= 0 default Infinity;
+
+param margin{PROD} >= 0;
+param h_dc{PROD} >= 0 default 0.01;             # weekly holding, DC
+param h_store{PROD} >= 0 default 0.02;          # weekly holding, store
+
+# -------- Decision variables --------
+var Inflow{PROD,WEEK} >= 0;                     # placed in week w
+var Push{PROD,STORE,WEEK} >= 0;
+var DCInv{PROD,WEEK} >= 0;
+var StInv{PROD,STORE,WEEK} >= 0;
+var Sold{j in PROD, st in STORE, w in WEEK} >= 0, <= demand[j,st,w];
+var Lost{j in PROD, st in STORE, w in WEEK} = demand[j,st,w] - Sold[j,st,w];
+
+# Receipts at the DC in week w
+var Arrive{j in PROD, w in WEEK} =
+    sum{w0 in WEEK: ord(w0) = ord(w) - lead} Inflow[j,w0];
+
+# ============================================================
+# Objective: margin on sales less holding
+# ============================================================
+maximize Prebuild_Profit:
+    sum{j in PROD, w in WEEK} (
+        sum{st in STORE} (margin[j] * Sold[j,st,w] - h_store[j] * StInv[j,st,w])
+      - h_dc[j] * DCInv[j,w]);
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) DC balance
+subject to DCBal{j in PROD, w in WEEK}:
+    DCInv[j,w] = (if ord(w) = 1 then dc_init[j]
+                  else sum{w0 in WEEK: ord(w0) = ord(w) - 1} DCInv[j,w0])
+               + Arrive[j,w] - sum{st in STORE} Push[j,st,w];
+
+# 2) Store balance
+subject to StoreBal{j in PROD, st in STORE, w in WEEK}:
+    StInv[j,st,w] = (if ord(w) = 1 then store_init[j,st]
+                     else sum{w0 in WEEK: ord(w0) = ord(w) - 1} StInv[j,st,w0])
+                  + Push[j,st,w] - Sold[j,st,w];
+
+# 3) Inflows that would land after the horizon are pointless
+subject to LateInflow{j in PROD, w in WEEK: ord(w) + lead > card(WEEK)}:
+    Inflow[j,w] = 0;
+
+# 4) Capacities
+subject to DCRecv{w in WEEK: dc_recv[w] < Infinity}:
+    sum{j in PROD} Arrive[j,w] <= dc_recv[w];
+
+subject to DCSpace{w in WEEK: dc_space < Infinity}:
+    sum{j in PROD} DCInv[j,w] <= dc_space;
+
+subject to Truck{st in STORE, w in WEEK: truck[st,w] < Infinity}:
+    sum{j in PROD} Push[j,st,w] <= truck[st,w];
+
+subject to StoreSpace{st in STORE, w in WEEK: store_space[st] < Infinity}:
+    sum{j in PROD} StInv[j,st,w] <= store_space[st];
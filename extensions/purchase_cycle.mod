@@ -0,0 +1,56 @@
+# ============================================================
+# APO-1 extension: Purchase-cycle (replenishment) cohorts
+# For products like pet food or diapers a buyer cohort that
+# purchases is out of the market for cyc[i] - 1 periods. A
+# segment in CYC is one such cohort; splitting a shopper base
+# into cohorts with staggered since_buy[i] gives the steady
+# state where about 1/cyc of it is due each period.
+#
+# A cohort is in the market (InMarket = 1) unless it bought
+# within its cycle, either in the horizon or before it. Only an
+# in-market cohort can buy and only it is held to max-surplus
+# choice, so a deep discount pulls a purchase forward and
+# leaves a dip afterwards instead of creating extra volume.
+#
+#   model APO-1.mod;  model extensions/purchase_cycle.mod;
+#   data "Sample 2.dat";  data extensions/purchase_cycle.dat;
+#   solve;  display InMarket, Cohort_Demand;
+# ============================================================
+
+set CYC within SEG;                           # replenishment cohorts
+
+param cyc{CYC} integer >= 1;                  # periods between purchases
+param since_buy{i in CYC} integer >= 1 default cyc[i];  # at horizon start
+
+# Out of the market because of a purchase before the horizon
+param pre_out{i in CYC, t in PER} binary :=
+    if ord(t) < 1 - since_buy[i] + cyc[i] then 1 else 0;
+
+param alpha_max{i in SEG, t in PER} := max{j in PROD} alpha[i,j,t];
+
+var InMarket{i in SEG, t in PER} =
+    if i in CYC then
+        1 - pre_out[i,t]
+          - sum{t0 in PER: ord(t0) < ord(t) and ord(t0) > ord(t) - cyc[i]}
+                sum{j in PROD} x[i,j,t0]
+    else 1;
+
+var Cohort_Demand{i in SEG, t in PER} =
+    sum{j in PROD} s[i] * x[i,j,t];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) At most one purchase per cycle (also keeps InMarket in {0,1})
+subject to CycleGap{i in CYC, t in PER}:
+    pre_out[i,t]
+  + sum{t0 in PER: ord(t0) <= ord(t) and ord(t0) > ord(t) - cyc[i]}
+        sum{j in PROD} x[i,j,t0] <= 1;
+
+# 2) Max-surplus choice only binds for in-market cohorts
+subject to UtilityChoice_Cyc{i in SEG, t in PER, j in PROD}:
+    sum{k in PROD} alpha[i,k,t] * x[i,k,t] - sum{k in PROD} g[i,k,t]
+    >= alpha[i,j,t] * z[j] - w[j,t] - alpha_max[i,t] * (1 - InMarket[i,t]);
+
+drop UtilityChoice;
@@ -0,0 +1,34 @@
+# ============================================================
+# Declarations for extensions/relax_suggest.run
+# Only constraints named in RS_CON are ranked: these are the
+# business limits a planner can actually move (capacities,
+# budgets, space). Structural rows (linearizations, inventory
+# balance, choice) also carry duals but cannot be relaxed, so
+# they are left out. An entry matches the whole indexed family,
+# e.g. "OrderCap" covers every OrderCap[j,t].
+#
+# The value reported and the duals are those of the objective in
+# use, i.e. the one the last `objective X;` (in an extension or
+# the caller's script) selected, not necessarily APO-1's Profit.
+#
+#   model ...;  model extensions/relax_suggest.mod;
+#   data ...;  solve;
+#   let RS_CON := RS_CON union {"ShelfSpace"};   # optional
+#   include extensions/relax_suggest.run;
+# ============================================================
+
+set RS_CON default {"OrderCap"};              # business constraint names
+param n_suggest integer >= 1 default 10;
+param bind_tol >= 0 default 1e-6;
+
+param rs_k integer >= 0 default 0;            # index into _obj
+param rs_profit;
+
+# Binding business constraints of the last solve, and their rank.
+# Constraint k belongs to family c when its name is c or c[...]
+set RS_BIND := {k in 1.._ncons:
+    (exists{c in RS_CON}
+        (_conname[k] = c
+         or substr(_conname[k], 1, length(c) + 1) = c & "["))
+    and abs(_con[k].slack) <= bind_tol and abs(_con[k].dual) > bind_tol};
+param rs_rank{1.._ncons} default 0;
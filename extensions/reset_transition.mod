@@ -0,0 +1,85 @@
+# ============================================================
+# Standalone model: Inventory transition after an assortment reset
+# After a reset, stores hold stock of items they no longer list
+# and nothing of their new items. The plan:
+#
+#   alloc  - opening DC allocation of each listed item
+#   xfer   - delisted stock moved to stores that still list it
+#   back   - delisted stock pulled back to the DC
+#   clear  - delisted stock marked down in place
+#
+# Every listed store should reach its opening target; any gap is
+# a shortfall charged at the lost margin. Positions are capped
+# at max_fill times target so transfers do not overstock.
+#
+# A store may also have to release part of the stock of an item
+# it keeps listing (release, e.g. the excess of a slow mover that
+# slow_movers.run hands over). The released units are moved like
+# delisted stock; the rest stays and counts toward the target.
+#
+#   model extensions/reset_transition.mod;
+#   data extensions/reset_transition.dat;
+#   data slow_movers_xfer.dat;          # optional
+#   solve;  include extensions/reset_transition.run;
+# ============================================================
+
+set PROD;
+set STORE;
+
+param listed{STORE,PROD} binary;               # after the reset
+param onhand{STORE,PROD} >= 0 default 0;
+param dc_stock{PROD} >= 0 default 0;
+param target{st in STORE, j in PROD} >= 0 default 0;  # opening stock
+param max_fill >= 1 default 1.5;
+param release{st in STORE, j in PROD} >= 0, <= onhand[st,j] default 0;
+
+param margin{PROD} >= 0;                       # lost per unit short
+param md_loss{PROD} >= 0;                      # loss per unit cleared
+param ship_cost{STORE} >= 0 default 0;         # DC -> store, per unit
+param back_cost{STORE} >= 0 default 0;         # store -> DC, per unit
+param xfer_cost{STORE,STORE} >= 0 default Infinity;  # store -> store
+
+# Units leaving each store, and units staying
+param out_qty{st in STORE, j in PROD} :=
+    if listed[st,j] = 0 then onhand[st,j] else release[st,j];
+param keep{st in STORE, j in PROD} := onhand[st,j] - out_qty[st,j];
+
+set RT_OUT := {st in STORE, j in PROD: out_qty[st,j] > 0};
+set RT_IN := {st in STORE, j in PROD: listed[st,j] = 1};
+set LANE := {(a,j) in RT_OUT, b in STORE: (b,j) in RT_IN and xfer_cost[a,b] < Infinity};
+
+# -------- Decision variables --------
+var alloc{RT_IN} >= 0;
+var xfer{LANE} >= 0;
+var back{RT_OUT} >= 0;
+var clear{RT_OUT} >= 0;
+var short{RT_IN} >= 0;
+
+# ============================================================
+# Objective: cheapest transition
+# ============================================================
+minimize Transition_Cost:
+    sum{(st,j) in RT_IN} (ship_cost[st] * alloc[st,j] + margin[j] * short[st,j])
+  + sum{(a,j,b) in LANE} xfer_cost[a,b] * xfer[a,j,b]
+  + sum{(st,j) in RT_OUT} (back_cost[st] * back[st,j] + md_loss[j] * clear[st,j]);
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Every delisted or released unit goes somewhere
+subject to Clearout{(a,j) in RT_OUT}:
+    sum{(a,j,b) in LANE} xfer[a,j,b] + back[a,j] + clear[a,j] = out_qty[a,j];
+
+# 2) Listed stores reach their target (or record the shortfall)
+subject to Opening{(b,j) in RT_IN}:
+    keep[b,j] + alloc[b,j] + sum{(a,j,b) in LANE} xfer[a,j,b] + short[b,j]
+    >= target[b,j];
+
+subject to MaxFill{(b,j) in RT_IN}:
+    keep[b,j] + alloc[b,j] + sum{(a,j,b) in LANE} xfer[a,j,b]
+    <= max(keep[b,j], max_fill * target[b,j]);
+
+# 3) DC stock
+subject to DCStock{j in PROD}:
+    sum{(st,j) in RT_IN} alloc[st,j] <= dc_stock[j];
@@ -0,0 +1,52 @@
+# ============================================================
+# APO-1 extension: Customer returns netting
+# A share ret_rate[j] of units sold comes back, spread over the
+# following periods by ret_lag. Returns are refunded at the sale
+# price, and the resellable share re-enters inventory, so orders
+# cover net rather than gross demand. Returned units that cannot
+# be sold before the horizon ends may be disposed of.
+#
+#   model APO-1.mod;  model extensions/returns.mod;
+#   data "Sample 2.dat";  data extensions/returns.dat;
+#   solve;  display Returns;
+# ============================================================
+
+param max_lag integer >= 1 default 2;
+set LAG := 1..max_lag;
+
+param ret_rate{PROD} >= 0, < 1 default 0;        # share of sales returned
+param ret_lag{PROD,LAG} >= 0 default 1 / max_lag; # lag distribution
+param resell{PROD} >= 0, <= 1 default 1;          # share fit for resale
+
+check{j in PROD}: abs(sum{l in LAG} ret_lag[j,l] - 1) <= 1e-6;
+
+# -------- Forecast return receipts --------
+var Returns{j in PROD, t in PER} =
+    ret_rate[j] * resell[j] *
+    sum{l in LAG: ord(t) - l >= 1} ret_lag[j,l] * d[j, member(ord(t) - l, PER)];
+
+var rdisp{PROD,PER} >= 0;                        # returned units disposed
+
+# -------- Objective (refunds deducted) --------
+maximize Profit_Net:
+    sum{t in PER, j in PROD} (
+        (1 - ret_rate[j]) * sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to DisposeCap{j in PROD, t in PER}:
+    rdisp[j,t] <= Returns[j,t];
+
+# Inventory balance with return receipts (replaces InvBal)
+subject to InvBal_Returns{j in PROD, t in PER: ord(t) > 1}:
+    I[j,t] = I[j,prev(t)] + u[j,t] + Returns[j,t] - rdisp[j,t] - d[j,t];
+
+drop InvBal;
+objective Profit_Net;
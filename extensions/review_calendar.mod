@@ -0,0 +1,23 @@
+# ============================================================
+# APO-1 extension: Review calendars (ragged ordering days)
+# PER may be at any granularity (e.g. days). Orders can only be
+# placed in periods that are review days on the product's
+# ordering calendar; stock must cover demand until the next one.
+#
+#   model APO-1.mod;  model extensions/review_calendar.mod;
+#   data "Sample 2.dat";  data extensions/review_calendar.dat;
+#   solve;
+# ============================================================
+
+# Location-wide ordering calendar (1 = review/order day)
+param review_day{PER} binary default 1;
+
+# Product-specific calendar; defaults to the location calendar
+param review{j in PROD, t in PER} binary default review_day[t];
+
+# Number of review days per product, for reporting
+param n_reviews{j in PROD} := sum{t in PER} review[j,t];
+
+# Orders (and their setups) only on review days
+subject to OrderOnReview{j in PROD, t in PER: review[j,t] = 0}:
+    y[j,t] = 0;
@@ -0,0 +1,45 @@
+# ============================================================
+# APO-1 extension: Dynamic safety stock from forecast errors
+# Safety stock per product is the empirical service-level
+# quantile of its recent forecast errors (actual - forecast),
+# recomputed every run, then clamped to floors/caps and to a
+# maximum change versus the previous run's value. Listed
+# products keep at least that stock at the end of every period
+# except the last (APO-1 sells down to zero).
+#
+#   model APO-1.mod;  model extensions/safety_stock.mod;
+#   data "Sample 2.dat";  data extensions/safety_stock.dat;
+#   solve;  display ss_raw, ss;
+# ============================================================
+
+set ERRH;                                  # recent error observations
+
+param fc_err{PROD,ERRH};                   # actual - forecast, units
+param service > 0, < 1 default 0.95;       # target cycle service level
+param service_j{PROD} > 0, < 1 default service;   # per product (config_hierarchy)
+
+param ss_floor{PROD} >= 0 default 0;
+param ss_cap{PROD} >= 0 default Infinity;
+param ss_prev{PROD} >= 0 default 0;        # last run (0 = none)
+param max_change >= 0 default 0.5;         # max relative move vs ss_prev
+
+param n_err := card(ERRH);
+check: n_err >= 1;
+
+# Empirical quantile: smallest error with at least service * n
+# observations at or below it
+param ss_raw{j in PROD} :=
+    max(0, min{e in ERRH:
+               card({e2 in ERRH: fc_err[j,e2] <= fc_err[j,e]}) >= service_j[j] * n_err}
+           fc_err[j,e]);
+
+param ss_clamped{j in PROD} := min(ss_cap[j], max(ss_floor[j], ss_raw[j]));
+
+param ss{j in PROD} :=
+    if ss_prev[j] = 0 then ss_clamped[j]
+    else min((1 + max_change) * ss_prev[j],
+             max((1 - max_change) * ss_prev[j], ss_clamped[j]));
+
+# Listed products hold their safety stock between orders
+subject to SafetyStock{j in PROD, t in PER: t <> last(PER) and ss[j] > 0}:
+    I[j,t] >= ss[j] * z[j];
@@ -0,0 +1,39 @@
+# ============================================================
+# APO-1 extension: Run-level KPI scorecard
+# After a solve, scorecard.run computes the run KPIs, compares
+# them with the current state and with the previous run, and
+# writes the scorecard as JSON and HTML. Each run also writes
+# its KPIs to prev_file as AMPL data; the next run reads that
+# file, when present, as its previous-run baseline.
+#
+# Expected stockouts are the units short under normal forecast
+# error with coefficient of variation fc_cv on the planned
+# demand, given the stock available in each period:
+#
+#   E[short] = sd * (phi(k) - k * (1 - Phi(k))),
+#   sd = fc_cv * d,  k = (stock available - d) / sd = I / sd
+#
+# with Phi taken from the logistic approximation
+# 1 / (1 + exp(-1.702 k)).
+#
+#   model APO-1.mod;  model extensions/scorecard.mod;
+#   data "Sample 2.dat";  data extensions/scorecard.dat;
+#   solve;  include extensions/scorecard.run;
+# ============================================================
+
+set KPI ordered := {"revenue", "margin", "inventory_value", "weeks_of_supply",
+                    "expected_stockouts", "price_index"};
+
+param run_id symbolic default "run";
+param json_file symbolic default "scorecard.json";
+param html_file symbolic default "scorecard.html";
+param prev_file symbolic default "scorecard_prev.dat";
+
+param sc_cur_price{PROD,PER} >= 0 default 0;  # current shelf prices (index base)
+param fc_cv{PROD} >= 0 default 0.25;          # forecast error, sd / mean
+param cur_kpi{KPI} default 0;                 # current-state KPIs
+param prev_kpi{KPI} default 0;                # previous run's KPIs (prev_file)
+param prev_run_id symbolic default "";
+
+param kpi{KPI} default 0;                     # this run (set by scorecard.run)
+param so_sd{PROD,PER} default 0;              # demand sd (set by scorecard.run)
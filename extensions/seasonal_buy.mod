@@ -0,0 +1,72 @@
+# ============================================================
+# Standalone model: Seasonal buy with a mid-season rebuy
+# Two-stage scenario tree for long-lead seasonal goods. The
+# initial buy Q is placed before the season. The first half
+# reveals which NODE the season is on; a rebuy R[j,n] can then
+# be placed for the second half (dearer, capacity-limited).
+# Leftover stock is salvaged at the end.
+#
+# With recourse = 0 the rebuy must be committed up front (same
+# in every node), so the profit difference between the two
+# runs is the value of the recourse (seasonal_buy.run).
+#
+#   model extensions/seasonal_buy.mod;
+#   data extensions/seasonal_buy.dat;
+#   include extensions/seasonal_buy.run;
+# ============================================================
+
+set PROD;
+set NODE;                                 # first-half outcomes
+set SCEN;                                 # full-season scenarios
+
+param node_of{SCEN} symbolic in NODE;
+param prob{SCEN} >= 0;
+check: abs(sum{s in SCEN} prob[s] - 1) < 1e-6;
+
+param d1{PROD,NODE} >= 0;                 # first-half demand
+param d2{PROD,SCEN} >= 0;                 # second-half demand
+
+param price{PROD} >= 0;
+param salvage{j in PROD} >= 0, <= price[j];
+param c0{PROD} >= 0;                      # initial buy cost
+param c1{PROD} >= 0;                      # rebuy cost
+param rcap{PROD} >= 0 default Infinity;   # rebuy capacity
+
+param recourse binary default 1;
+
+param pn{n in NODE} := sum{s in SCEN: node_of[s] = n} prob[s];
+
+# -------- Decision variables --------
+var Q{PROD} >= 0;                         # initial buy
+var R{j in PROD, NODE} >= 0, <= rcap[j];  # mid-season rebuy
+var Rc{PROD} >= 0;                        # committed rebuy (recourse = 0)
+var S1{j in PROD, n in NODE} >= 0, <= d1[j,n];
+var S2{j in PROD, s in SCEN} >= 0, <= d2[j,s];
+
+# ============================================================
+# Objective: expected season profit
+# ============================================================
+maximize Expected_Profit:
+    sum{j in PROD} (
+        - c0[j] * Q[j]
+        + sum{n in NODE} pn[n] * (price[j] * S1[j,n] - c1[j] * R[j,n])
+        + sum{s in SCEN} prob[s] * (
+              price[j] * S2[j,s]
+            + salvage[j] * (Q[j] + R[j,node_of[s]]
+                            - S1[j,node_of[s]] - S2[j,s])));
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) First-half sales come from the initial buy
+subject to FirstHalf{j in PROD, n in NODE}:
+    S1[j,n] <= Q[j];
+
+# 2) Second-half sales from leftover plus rebuy
+subject to SecondHalf{j in PROD, s in SCEN}:
+    S2[j,s] <= Q[j] - S1[j,node_of[s]] + R[j,node_of[s]];
+
+# 3) Without recourse the rebuy cannot react to the first half
+subject to Committed{j in PROD, n in NODE: recourse = 0}:
+    R[j,n] = Rc[j];
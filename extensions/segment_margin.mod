@@ -0,0 +1,47 @@
+# ============================================================
+# APO-1 extension: Segment-level margin reporting
+# One shelf price per product serves a mixture of segments, each
+# with its own reservation prices. This reports units, revenue
+# and margin per segment for the optimized prices and for a
+# reference price set (e.g. current shelf prices), so the margin
+# impact of the recommendation can be read per segment.
+#
+# Segment margin charges the unit cost of the period of sale.
+#
+#   model APO-1.mod;  model extensions/segment_margin.mod;
+#   data "Sample 2.dat";  data extensions/segment_margin.dat;
+#   solve;  display SegMargin, ref_margin, margin_impact;
+# ============================================================
+
+# -------- Optimized plan, per segment --------
+var SegUnits{i in SEG, t in PER} =
+    sum{j in PROD} s[i] * x[i,j,t];
+
+var SegRevenue{i in SEG, t in PER} =
+    sum{j in PROD} s[i] * g[i,j,t];
+
+var SegMargin{i in SEG} =
+    sum{t in PER, j in PROD} s[i] * (g[i,j,t] - c[j,t] * x[i,j,t]);
+
+# -------- Reference prices, evaluated with the same choice rule --------
+param ref_price{PROD,PER} >= 0 default 0;     # 0 = not offered
+
+param ref_surplus{i in SEG, j in PROD, t in PER} :=
+    alpha[i,j,t] - ref_price[j,t];
+
+# Best non-negative surplus among offered products (-1 = buy nothing)
+param ref_best{i in SEG, t in PER} :=
+    max(-1, max{j in PROD: ref_price[j,t] > 0 and ref_surplus[i,j,t] >= 0} ref_surplus[i,j,t]);
+
+# Ties are split evenly between the tied products
+param ref_ntied{i in SEG, t in PER} :=
+    card({j in PROD: ref_price[j,t] > 0 and ref_surplus[i,j,t] = ref_best[i,t]});
+
+param ref_buy{i in SEG, j in PROD, t in PER} :=
+    if ref_best[i,t] >= 0 and ref_price[j,t] > 0 and ref_surplus[i,j,t] = ref_best[i,t]
+    then 1 / ref_ntied[i,t] else 0;
+
+param ref_margin{i in SEG} :=
+    sum{t in PER, j in PROD} s[i] * ref_buy[i,j,t] * (ref_price[j,t] - c[j,t]);
+
+var margin_impact{i in SEG} = SegMargin[i] - ref_margin[i];
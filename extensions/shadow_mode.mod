@@ -0,0 +1,48 @@
+# ============================================================
+# APO-1 extension: Shadow mode
+# The model runs next to the legacy process without exporting
+# anything. shadow_mode.run solves for its own recommendation,
+# then re-solves with the legacy assortment and prices fixed to
+# value what was actually done under the same model. Once the
+# period has run, realized_units show how well the model
+# predicted the executed (legacy) plan. Each run appends one
+# line to log_file, so evidence builds up before cut-over.
+#
+#   model APO-1.mod;  model extensions/shadow_mode.mod;
+#   data "Sample 2.dat";  data extensions/shadow_mode.dat;
+#   include extensions/shadow_mode.run;
+# ============================================================
+
+param sh_run_id symbolic default "run";
+param log_file symbolic default "shadow_log.csv";
+
+param legacy_z{PROD} binary;                       # legacy assortment
+param legacy_p{j in PROD, t in PER} >= 0, <= p_ub[j,t];  # legacy prices
+param realized_units{PROD,PER} default -1;         # -1 = not yet known
+
+check{j in PROD, t in PER}: legacy_z[j] = 1 or legacy_p[j,t] = 0;
+
+# -------- Filled by shadow_mode.run --------
+param sh_rec_z{PROD} default 0;
+param sh_rec_p{PROD,PER} default 0;
+param sh_rec_profit default 0;
+param leg_profit default 0;                        # model value of legacy plan
+param leg_units{PROD,PER} default 0;               # model units of legacy plan
+
+param n_assort_diff := sum{j in PROD} abs(sh_rec_z[j] - legacy_z[j]);
+
+# Mean absolute relative price gap where both carry the item
+param price_gap :=
+    if card({j in PROD: sh_rec_z[j] = 1 and legacy_z[j] = 1}) = 0 then 0
+    else sum{j in PROD, t in PER: sh_rec_z[j] = 1 and legacy_z[j] = 1 and legacy_p[j,t] > 0}
+            abs(sh_rec_p[j,t] - legacy_p[j,t]) / legacy_p[j,t]
+       / max(1, card({j in PROD, t in PER:
+                      sh_rec_z[j] = 1 and legacy_z[j] = 1 and legacy_p[j,t] > 0}));
+
+# Forecast error of the model on the executed plan
+set KNOWN := {j in PROD, t in PER: realized_units[j,t] >= 0};
+
+param wape :=
+    if sum{(j,t) in KNOWN} realized_units[j,t] = 0 then -1
+    else sum{(j,t) in KNOWN} abs(leg_units[j,t] - realized_units[j,t])
+       / sum{(j,t) in KNOWN} realized_units[j,t];
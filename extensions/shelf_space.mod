@@ -0,0 +1,25 @@
+# ============================================================
+# APO-1 extension: Shelf space and SKU slots per fixture
+# Listed products take shelf width on their fixture, and each
+# fixture holds a limited number of SKUs. shelf_space.run solves
+# the LP relaxation and reports the dual values as shadow prices:
+# the marginal profit of one more unit of width or one more SKU
+# slot per fixture.
+#
+#   model APO-1.mod;  model extensions/shelf_space.mod;
+#   data "Sample 2.dat";  data extensions/shelf_space.dat;
+#   include extensions/shelf_space.run;
+# ============================================================
+
+set FIXTURE;
+
+param fixture_of{PROD} symbolic in FIXTURE;
+param width{PROD} >= 0;                   # shelf width (e.g. feet) when listed
+param space{FIXTURE} >= 0;                # width available
+param slots{FIXTURE} >= 0 default Infinity;  # max SKUs on the fixture
+
+subject to ShelfSpace{fx in FIXTURE}:
+    sum{j in PROD: fixture_of[j] = fx} width[j] * z[j] <= space[fx];
+
+subject to SkuSlots{fx in FIXTURE}:
+    sum{j in PROD: fixture_of[j] = fx} z[j] <= slots[fx];
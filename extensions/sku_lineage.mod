@@ -0,0 +1,51 @@
+# ============================================================
+# Standalone model: SKU lineage and history stitching
+# History is recorded under whatever code a SKU had at the time.
+# LINK holds the lineage: (old, new) with the period eff[old,new]
+# from which new replaces old, and the share of old's demand it
+# inherits (1 for a renumbering; shares sum to 1 over the
+# successors of a split; several olds into one new is a merge).
+#
+# Sales of a code before a link's effective period flow to its
+# successor, through any number of steps, to the codes in use
+# today (CURRENT). Category history is then restated under each
+# current SKU's current category, so category moves do not
+# break it.
+#
+#   model extensions/sku_lineage.mod;
+#   data extensions/sku_lineage.dat;
+#   display stitched, cat_hist;
+# ============================================================
+
+set CODE;                                  # every code seen in history
+set HIST ordered;
+set CAT;
+
+param sales{CODE,HIST} >= 0 default 0;     # as recorded
+
+set LINK within {CODE,CODE};               # (old, new)
+param eff{LINK} symbolic in HIST;          # first period under new
+param share{LINK} >= 0, <= 1 default 1;
+
+check{(a,b) in LINK}: a <> b;
+check{a in CODE: exists{b in CODE} (a,b) in LINK}:
+    abs(sum{(a,b) in LINK} share[a,b] - 1) < 1e-6;
+
+set CURRENT := {c in CODE: not exists{b in CODE} (c,b) in LINK};
+param cat_of{CURRENT} symbolic in CAT;     # today's category
+
+# -------- Attribution of a code's sales in h to current SKUs --------
+# Defined recursively along the lineage (which must be acyclic).
+param attr{c in CODE, r in CURRENT, h in HIST} :=
+    if c = r then 1
+    else sum{(c,b) in LINK: ord(h) < ord(eff[c,b], HIST)} share[c,b] * attr[b,r,h];
+
+param stitched{r in CURRENT, h in HIST} :=
+    sum{c in CODE} attr[c,r,h] * sales[c,h];
+
+param cat_hist{k in CAT, h in HIST} :=
+    sum{r in CURRENT: cat_of[r] = k} stitched[r,h];
+
+# Recorded sales that reach no current SKU (e.g. dropped codes)
+param unmapped{h in HIST} :=
+    sum{c in CODE} sales[c,h] - sum{r in CURRENT} stitched[r,h];
@@ -0,0 +1,102 @@
+# ============================================================
+# Standalone model: Dead stock and slow-mover diagnostics
+# Scores each SKU on its sales history and stock:
+#
+#   sell-through = sales / (sales + on hand)   over the history
+#   cover        = on hand / avg weekly sales  (weeks)
+#   trend        = recent avg / earlier avg    (last n_recent weeks)
+#
+# and classifies it:
+#
+#   dead     - stock on hand, no sales in the last n_dead weeks
+#   slow     - cover above max_cover, sell-through below min_st
+#              or trend below min_trend
+#   healthy  - otherwise
+#
+# Each dead or slow SKU is routed to one action:
+#
+#   dead, vendor takes returns          -> rtv
+#   dead, otherwise                     -> delist
+#   slow, excess that other stores need -> transfer
+#   slow, otherwise                     -> markdown
+#
+# The model shares no declarations with APO-1, so the actions
+# are handed on as files that slow_movers.run writes:
+#
+#   sm_lock_file     lock_z = 0 for rtv and delist SKUs, an
+#                    override round for planner_locks.run
+#   sm_runoff_prefix & j & ".dat"
+#                    stock0, rtv_cap and rate of SKU j for
+#                    delist_runoff (rtv, delist and markdown)
+#   sm_xfer_file     release of the transfer SKUs' excess from
+#                    store sm_store for reset_transition
+#
+#   model extensions/slow_movers.mod;
+#   data extensions/slow_movers.dat;
+#   include extensions/slow_movers.run;
+# ============================================================
+
+set PROD;
+set HIST ordered;                             # past weeks, oldest first
+
+param sales_hist{PROD,HIST} >= 0;
+param onhand{PROD} >= 0;
+param rtv_ok{PROD} binary default 0;          # vendor accepts returns
+param need_elsewhere{PROD} >= 0 default 0;    # units other stores could use
+
+param n_recent integer >= 1 default 4;
+param n_dead integer >= 1 default 6;
+param max_cover >= 0 default 12;              # weeks
+param min_st >= 0, <= 1 default 0.3;
+param min_trend >= 0 default 0.5;             # recent vs earlier sales
+param target_cover >= 0 default 4;            # weeks kept when acting
+
+param sm_store symbolic default "S1";         # store being diagnosed
+param sm_horizon integer >= 1 default 6;      # runoff periods
+param sm_lock_file symbolic default "slow_movers_locks.dat";
+param sm_runoff_prefix symbolic default "slow_runoff_";
+param sm_xfer_file symbolic default "slow_movers_xfer.dat";
+
+check: n_recent < card(HIST) and n_dead <= card(HIST);
+
+# -------- Scores --------
+param tot_sales{j in PROD} := sum{h in HIST} sales_hist[j,h];
+param avg_sales{j in PROD} := tot_sales[j] / card(HIST);
+
+param sell_through{j in PROD} :=
+    if tot_sales[j] + onhand[j] > 0 then tot_sales[j] / (tot_sales[j] + onhand[j]) else 1;
+
+param cover{j in PROD} :=
+    if avg_sales[j] > 0 then onhand[j] / avg_sales[j]
+    else if onhand[j] > 0 then Infinity else 0;
+
+param recent{j in PROD} :=
+    sum{h in HIST: ord(h) > card(HIST) - n_recent} sales_hist[j,h] / n_recent;
+param earlier{j in PROD} :=
+    sum{h in HIST: ord(h) <= card(HIST) - n_recent} sales_hist[j,h]
+  / (card(HIST) - n_recent);
+param trend{j in PROD} :=
+    if earlier[j] > 0 then recent[j] / earlier[j] else if recent[j] > 0 then 2 else 1;
+
+# -------- Classification --------
+param status{j in PROD} symbolic :=
+    if onhand[j] > 0
+       and sum{h in HIST: ord(h) > card(HIST) - n_dead} sales_hist[j,h] = 0
+    then "dead"
+    else if cover[j] > max_cover or sell_through[j] < min_st
+            or trend[j] < min_trend then "slow"
+    else "healthy";
+
+param excess{j in PROD} := max(0, onhand[j] - target_cover * recent[j]);
+
+param action{j in PROD} symbolic :=
+    if status[j] = "dead" then (if rtv_ok[j] = 1 then "rtv" else "delist")
+    else if status[j] = "slow" then
+        (if excess[j] > 0 and need_elsewhere[j] >= excess[j]
+         then "transfer" else "markdown")
+    else "none";
+
+set RTV      := {j in PROD: action[j] = "rtv"};
+set DELIST   := {j in PROD: action[j] = "delist"};
+set TRANSFER := {j in PROD: action[j] = "transfer"};
+set MARKDOWN := {j in PROD: action[j] = "markdown"};
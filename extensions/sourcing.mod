@@ -0,0 +1,75 @@
+# ============================================================
+# Standalone model: DC-to-store sourcing matrix
+# Store needs per SKU are sourced from DCs by a sourcing matrix:
+# split[j,st,dc] is the normal share of store st's need for j
+# served by dc (shares sum to 1), and ALT lists the DCs allowed
+# to step in. While a DC is out (outage[dc,t] = 1) its share is
+# re-sourced over the alternates at least cost within DC
+# capacity; DCs that are up keep exactly their normal share.
+# Need that cannot be covered is reported as uncovered.
+# DC_Need aggregates store needs to the DCs that serve them,
+# which is what DC replenishment plans against.
+#
+#   model extensions/sourcing.mod;
+#   data extensions/sourcing.dat;
+#   solve;  display DC_Need, Uncovered;
+# ============================================================
+
+set PROD;
+set STORE;
+set DC;
+set PER ordered;
+
+param need{PROD,STORE,PER} >= 0;               # store replenishment need
+param split{PROD,STORE,DC} >= 0, <= 1 default 0;
+set ALT within {PROD,STORE,DC} default {};     # alternate sources
+
+check{j in PROD, st in STORE}: abs(sum{dc in DC} split[j,st,dc] - 1) < 1e-6;
+
+param outage{DC,PER} binary default 0;
+param dc_cap{DC,PER} >= 0 default Infinity;    # units shipped per period
+param lane_cost{DC,STORE} >= 0 default 0;      # per unit
+param uncovered_pen >= 0 default 1000;
+
+set LANE := {j in PROD, st in STORE, dc in DC:
+             split[j,st,dc] > 0 or (j,st,dc) in ALT};
+
+# -------- Decision variables --------
+var Flow{LANE,PER} >= 0;
+var Uncovered{PROD,STORE,PER} >= 0;
+
+var DC_Need{dc in DC, j in PROD, t in PER} =
+    sum{(j,st,dc) in LANE} Flow[j,st,dc,t];
+
+# ============================================================
+# Objective: lane cost plus uncovered need
+# ============================================================
+minimize Sourcing_Cost:
+    sum{(j,st,dc) in LANE, t in PER} lane_cost[dc,st] * Flow[j,st,dc,t]
+  + uncovered_pen * sum{j in PROD, st in STORE, t in PER} Uncovered[j,st,t];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+# 1) Every need is sourced (or reported uncovered)
+subject to Cover{j in PROD, st in STORE, t in PER}:
+    sum{(j,st,dc) in LANE} Flow[j,st,dc,t] + Uncovered[j,st,t] = need[j,st,t];
+
+# 2) A DC that is up serves at least its normal share
+subject to NormalShare{(j,st,dc) in LANE, t in PER:
+        split[j,st,dc] > 0 and outage[dc,t] = 0}:
+    Flow[j,st,dc,t] >= split[j,st,dc] * need[j,st,t] - Uncovered[j,st,t];
+
+# 3) Beyond its share a DC ships only as an alternate during an outage
+subject to AltOnly{(j,st,dc) in LANE, t in PER}:
+    Flow[j,st,dc,t] <=
+        (if outage[dc,t] = 1 then 0
+         else split[j,st,dc] * need[j,st,t]
+            + (if (j,st,dc) in ALT then
+                  sum{dc2 in DC: outage[dc2,t] = 1} split[j,st,dc2] * need[j,st,t]
+               else 0));
+
+# 4) DC shipping capacity
+subject to DCCap{dc in DC, t in PER: dc_cap[dc,t] < Infinity}:
+    sum{(j,st,dc) in LANE} Flow[j,st,dc,t] <= dc_cap[dc,t];
@@ -0,0 +1,46 @@
+# ============================================================
+# APO-1 extension: Stability among near-optimal plans
+# When many prices are nearly equivalent, small input changes
+# flip the recommendation. This solves in two phases: first
+# for the best profit, then, among plans within opt_tol of it,
+# for the one closest to last run's plan (price moves weighted
+# by wt_price, listing flips by wt_flip).
+#
+#   ampl extensions/stability.run
+# ============================================================
+
+param last_z{PROD} binary default 0;           # last run's listing
+param last_p{PROD,PER} >= 0 default 0;         # last run's prices
+
+param opt_tol >= 0, < 1 default 0.005;         # allowed profit give-up
+param wt_price >= 0 default 1;                 # per unit of price moved
+param wt_flip >= 0 default 1;                  # per listing change
+
+param best_profit default -Infinity;           # set by stability.run
+
+var PriceUp{PROD,PER} >= 0;
+var PriceDown{PROD,PER} >= 0;
+
+# ============================================================
+# Objective: churn versus last run
+# ============================================================
+minimize Churn:
+    wt_price * sum{j in PROD, t in PER} (PriceUp[j,t] + PriceDown[j,t])
+  + wt_flip * sum{j in PROD} (if last_z[j] = 1 then 1 - z[j] else z[j]);
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to PriceMove{j in PROD, t in PER}:
+    p[j,t] - last_p[j,t] = PriceUp[j,t] - PriceDown[j,t];
+
+subject to NearOptimal{if best_profit > -Infinity}:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j]
+    >= best_profit - opt_tol * abs(best_profit);
@@ -0,0 +1,29 @@
+# ============================================================
+# APO-1 extension: Storage-class capacity (cold chain)
+# Products belong to a storage class (frozen, chilled, ambient)
+# with limited cube per store. Listed products reserve their
+# display cube in their class, and the stock on hand right after
+# receipt (opening stock + order) must fit in the class cube.
+#
+#   model APO-1.mod;  model extensions/storage_class.mod;
+#   data "Sample 2.dat";  data extensions/storage_class.dat;
+#   solve;
+# ============================================================
+
+set SCLASS;                                   # storage classes
+
+param sclass_of{PROD} symbolic in SCLASS;
+param cube{PROD} > 0;                         # cube per unit
+param disp_cube{PROD} >= 0 default 0;         # cube reserved when listed
+param class_cap{SCLASS,PER} >= 0 default Infinity;  # cube available
+
+# 1) Assortment: display cube of listed products fits the class
+subject to ClassDisplay{sc in SCLASS, t in PER: class_cap[sc,t] < Infinity}:
+    sum{j in PROD: sclass_of[j] = sc} disp_cube[j] * z[j] <= class_cap[sc,t];
+
+# 2) Inventory: peak stock after receipt fits next to the displays
+subject to ClassStock{sc in SCLASS, t in PER: class_cap[sc,t] < Infinity}:
+    sum{j in PROD: sclass_of[j] = sc}
+        (cube[j] * (sum{t0 in PER: ord(t0) = ord(t) - 1} I[j,t0] + u[j,t])
+         + disp_cube[j] * z[j])
+    <= class_cap[sc,t];
@@ -0,0 +1,60 @@
+# ============================================================
+# Standalone model: Event-aware demand history
+# A store-event table cleans per-store sales history before it
+# is used for estimation:
+#
+#   opening  - periods before opened[s] are not history
+#   remodel  - closure weeks in REMODEL are excluded
+#   closure  - a store in CLOSED has no history from closed_at on;
+#              share xfer[c,s] of its demand is recaptured by
+#              nearby store s, so s's pre-closure history is
+#              lifted by that share of c's sales
+#
+# rate[j,s] is the adjusted average demand per period over the
+# usable periods and can feed s in APO-1 for store s.
+#
+#   model extensions/store_events.mod;
+#   data extensions/store_events.dat;
+#   include extensions/store_events.run;
+# ============================================================
+
+set PROD;
+set STORE;
+set HIST ordered;                    # past periods, oldest first
+
+param sales_hist{PROD,STORE,HIST} >= 0;
+
+# -------- Store-event table --------
+param opened{STORE} symbolic in HIST default first(HIST);
+set REMODEL within {STORE,HIST} default {};
+set CLOSED within STORE default {};
+param closed_at{CLOSED} symbolic in HIST;
+param xfer{CLOSED,STORE} >= 0, <= 1 default 0;
+
+check {c in CLOSED}: xfer[c,c] = 0;
+check {c in CLOSED}: sum{s in STORE} xfer[c,s] <= 1;
+
+# -------- Usable periods --------
+param use_hist{s in STORE, h in HIST} binary :=
+    if ord(h) < ord(opened[s], HIST) then 0
+    else if (s,h) in REMODEL then 0
+    else if s in CLOSED and ord(h) >= ord(closed_at[s], HIST) then 0
+    else 1;
+
+param n_use{s in STORE} := sum{h in HIST} use_hist[s,h];
+
+# -------- Adjusted history --------
+# Recapture only applies before the closure; after it the
+# transferred demand is already in the receiving store's sales.
+param sales_adj{j in PROD, s in STORE, h in HIST} :=
+    if use_hist[s,h] = 0 then 0
+    else sales_hist[j,s,h]
+       + sum{c in CLOSED: ord(h) < ord(closed_at[c], HIST) and use_hist[c,h] = 1}
+            xfer[c,s] * sales_hist[j,c,h];
+
+param rate{j in PROD, s in STORE} :=
+    if n_use[s] = 0 then 0
+    else (sum{h in HIST} sales_adj[j,s,h]) / n_use[s];
+
+param rate_raw{j in PROD, s in STORE} :=
+    (sum{h in HIST} sales_hist[j,s,h]) / card(HIST);
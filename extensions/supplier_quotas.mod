@@ -0,0 +1,36 @@
+# ============================================================
+# APO-1 extension: Supplier diversity and strategic quotas
+#   - at least min_local SKUs from local suppliers
+#   - per-supplier SKU count quotas (min/max)
+#   - no supplier above share_max of the listed shelf width
+# supplier_quotas.run re-solves with each constraint group
+# dropped to report its profit impact.
+#
+#   model APO-1.mod;  model extensions/supplier_quotas.mod;
+#   data "Sample 2.dat";  data extensions/supplier_quotas.dat;
+#   include extensions/supplier_quotas.run;
+# ============================================================
+
+set SUPPLIER;
+set LOCAL_SUPP within SUPPLIER default {};
+
+param supplier_of{PROD} symbolic in SUPPLIER;
+param shelf_wd{PROD} > 0 default 1;                 # shelf width per SKU
+
+param min_local integer >= 0 default 0;
+param sku_min{SUPPLIER} integer >= 0 default 0;
+param sku_max{SUPPLIER} integer >= 0 default card(PROD);
+param share_max{SUPPLIER} >= 0, <= 1 default 1;
+
+subject to LocalMin:
+    sum{j in PROD: supplier_of[j] in LOCAL_SUPP} z[j] >= min_local;
+
+subject to SupplierMin{v in SUPPLIER: sku_min[v] > 0}:
+    sum{j in PROD: supplier_of[j] = v} z[j] >= sku_min[v];
+
+subject to SupplierMax{v in SUPPLIER: sku_max[v] < card(PROD)}:
+    sum{j in PROD: supplier_of[j] = v} z[j] <= sku_max[v];
+
+subject to ShelfShare{v in SUPPLIER: share_max[v] < 1}:
+    sum{j in PROD: supplier_of[j] = v} shelf_wd[j] * z[j]
+    <= share_max[v] * sum{j in PROD} shelf_wd[j] * z[j];
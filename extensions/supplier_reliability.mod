@@ -0,0 +1,83 @@
+# ============================================================
+# APO-1 extension: Supplier reliability and lead-time bias
+# Purchase-order receipt history gives each vendor's fill rate
+# (units received / units ordered) and lateness versus the
+# promised lead time. The effective lead time is the promised
+# one plus the mean lateness or its late_q quantile (rounded to
+# whole periods), and only the fill-rate share of an order is
+# received and invoiced.
+# Replaces APO-1's inventory balance, order cap and objective.
+#
+# Vendors come from vendors.mod:
+#   model APO-1.mod;  model extensions/vendors.mod;
+#   model extensions/supplier_reliability.mod;
+#   data "Sample 2.dat";  data extensions/vendors.dat;
+#   data extensions/supplier_reliability.dat;
+#   solve;  display fill_rate, lead_eff;
+# ============================================================
+
+set PO;                                        # receipt history
+
+param lead_promised{VEND} integer >= 0 default 0;   # in periods
+
+param po_vendor{PO} symbolic in VEND;
+param po_qty{PO} > 0;                          # units ordered
+param po_recv{PO} >= 0;                        # units received
+param po_late{PO};                             # periods late (< 0 = early)
+
+param lead_basis symbolic in {"mean", "quantile"} default "mean";
+param late_q > 0, < 1 default 0.9;
+
+set VPO{v in VEND} := {o in PO: po_vendor[o] = v};
+
+# -------- Learned reliability --------
+param fill_rate{v in VEND} :=
+    if card(VPO[v]) = 0 then 1
+    else min(1, sum{o in VPO[v]} po_recv[o] / sum{o in VPO[v]} po_qty[o]);
+
+param late_mean{v in VEND} :=
+    if card(VPO[v]) = 0 then 0
+    else sum{o in VPO[v]} po_late[o] / card(VPO[v]);
+
+param late_quant{v in VEND} :=
+    if card(VPO[v]) = 0 then 0
+    else min{o in VPO[v]:
+             card({o2 in VPO[v]: po_late[o2] <= po_late[o]}) >= late_q * card(VPO[v])}
+         po_late[o];
+
+param lead_eff{v in VEND} integer :=
+    max(0, round(lead_promised[v]
+                 + (if lead_basis = "mean" then late_mean[v] else late_quant[v])));
+
+# Receipts in t from orders placed lead_eff periods earlier
+var Receipt{j in PROD, t in PER} =
+    fill_rate[vendor[j]] *
+    sum{t0 in PER: ord(t0) = ord(t) - lead_eff[vendor[j]]} u[j,t0];
+
+# -------- Objective (received units are invoiced) --------
+maximize Profit_Reliable:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * fill_rate[vendor[j]] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to InvBal_First_Reliable{j in PROD, t in first(PER)}:
+    I[j,t] = Receipt[j,t] - d[j,t];
+
+subject to InvBal_Reliable{j in PROD, t in PER: ord(t) > 1}:
+    I[j,t] = I[j,prev(t)] + Receipt[j,t] - d[j,t];
+
+# Short-shipping vendors need proportionally larger orders
+subject to OrderCap_Reliable{j in PROD, t in PER}:
+    u[j,t] <= y[j,t] * ((card(PER) - ord(t) + 1) * S_total)
+              / max(fill_rate[vendor[j]], 0.01);
+
+drop InvBal_First;  drop InvBal;  drop OrderCap;
+objective Profit_Reliable;
@@ -0,0 +1,31 @@
+# ============================================================
+# APO-1 extension: Per-category time budgets (anytime runs)
+# The nightly batch solves one category at a time, each with
+# its own time limit, and the batch as a whole must finish
+# within batch_budget seconds. Every category returns a plan:
+# the best incumbent found when its time runs out, with the
+# solver's bound and the resulting gap. If no incumbent exists
+# the category falls back to the empty assortment (always
+# feasible) and is marked so.
+#
+#   ampl extensions/time_budget.run
+# ============================================================
+
+set BATCH;                                    # solved one at a time
+param batch_of{PROD} symbolic in BATCH;
+
+param budget{BATCH} > 0 default 60;           # seconds per category
+param batch_budget > 0 default 3600;          # seconds for the batch
+param min_time > 0 default 1;                 # floor per category
+param feas_tol > 0 default 1e-6;
+
+# -------- Per-category results (filled by time_budget.run) --------
+param b_time{BATCH} default 0;                # seconds granted
+param b_status{BATCH} symbolic default "";
+param b_profit{BATCH} default 0;              # incumbent
+param b_bound{BATCH} default 0;               # best bound
+param b_gap{b in BATCH} :=
+    if abs(b_bound[b]) < 1e-9 then 0
+    else abs(b_bound[b] - b_profit[b]) / max(abs(b_bound[b]), 1e-9);
+param b_fallback{BATCH} binary default 0;
+param z_plan{PROD} default 0;
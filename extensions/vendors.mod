@@ -0,0 +1,16 @@
+# ============================================================
+# APO-1 extension: Sourcing vendors (shared)
+# Declares the sourcing vendor of every product once, for the
+# vendor-level extensions (lot_sizing, supplier_reliability).
+# Load it after APO-1.mod and before any of them, so that they
+# can be stacked on the same vendors.
+#
+#   model APO-1.mod;  model extensions/vendors.mod;
+#   model extensions/lot_sizing.mod;
+#   data "Sample 2.dat";  data extensions/vendors.dat;
+#   data extensions/lot_sizing.dat;
+# ============================================================
+
+set VEND;                                   # vendors
+
+param vendor{PROD} symbolic in VEND;        # sourcing vendor of j
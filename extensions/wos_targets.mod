@@ -0,0 +1,74 @@
+# ============================================================
+# APO-1 extension: Sell-through and weeks-of-supply targets
+# Merchandising sets a weeks-of-supply band and a sell-through
+# target per category, both as soft constraints. End-of-period
+# category stock is measured against the next period's category
+# demand (periods are weeks):
+#
+#   wos_lo * demand next week <= stock <= wos_hi * demand next week
+#
+# Sell-through is the share of the stock available in a period
+# (opening stock plus receipts = sales + closing stock) that
+# sells in it:
+#
+#   sales >= st_target * (sales + closing stock)
+#
+# Deviations in units are allowed but charged at pen_under /
+# pen_over / pen_st per unit, so stock is pulled toward the
+# targets rather than forced onto them. The last period has no
+# forward demand and is not tracked for WOS. Categories come
+# from categories.mod.
+#
+#   model APO-1.mod;  model extensions/categories.mod;
+#   model extensions/wos_targets.mod;
+#   data "Sample 2.dat";  data extensions/categories.dat;
+#   data extensions/wos_targets.dat;
+#   solve;  include extensions/wos_targets.run;
+# ============================================================
+
+param wos_lo{CAT} >= 0;
+param wos_hi{k in CAT} >= wos_lo[k];
+param pen_under{CAT} >= 0 default 0.05;      # per unit below the band
+param pen_over{CAT} >= 0 default 0.02;       # per unit above the band
+param st_target{CAT} >= 0, <= 1 default 0;   # sell-through per period
+param pen_st{CAT} >= 0 default 0.05;         # per unit short of it
+
+set TRACK := {t in PER: ord(t) < card(PER)};
+
+var CatStock{k in CAT, t in PER} = sum{j in PROD: cat_of[j] = k} I[j,t];
+var CatFwd{k in CAT, t in TRACK} = sum{j in PROD: cat_of[j] = k} d[j,next(t)];
+
+var CatSales{k in CAT, t in PER} = sum{j in PROD: cat_of[j] = k} d[j,t];
+
+var Under{CAT,TRACK} >= 0;                   # units below the band
+var Over{CAT,TRACK} >= 0;                    # units above the band
+var STShort{CAT,PER} >= 0;                   # sales short of sell-through
+
+# ============================================================
+# Objective: profit less WOS and sell-through penalties
+# ============================================================
+maximize Profit_WOS:
+    sum{t in PER, j in PROD} (
+        sum{i in SEG} s[i] * g[i,j,t]
+        - K[j,t] * y[j,t]
+        - c[j,t] * u[j,t]
+        - h[j,t] * I[j,t]
+    )
+  - sum{j in PROD} f[j] * z[j]
+  - sum{k in CAT, t in TRACK} (pen_under[k] * Under[k,t] + pen_over[k] * Over[k,t])
+  - sum{k in CAT, t in PER} pen_st[k] * STShort[k,t];
+
+# ============================================================
+# Constraints
+# ============================================================
+
+subject to WOSLow{k in CAT, t in TRACK}:
+    CatStock[k,t] + Under[k,t] >= wos_lo[k] * CatFwd[k,t];
+
+subject to WOSHigh{k in CAT, t in TRACK}:
+    CatStock[k,t] - Over[k,t] <= wos_hi[k] * CatFwd[k,t];
+
+subject to SellThrough{k in CAT, t in PER: st_target[k] > 0}:
+    CatSales[k,t] + STShort[k,t] >= st_target[k] * (CatSales[k,t] + CatStock[k,t]);
+
+objective Profit_WOS;